@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/formatting"
+	"go.uber.org/zap"
+)
+
+// Asset describes an X-Chain asset.
+type Asset struct {
+	ID           ids.ID
+	Name         string
+	Symbol       string
+	Denomination uint8
+}
+
+// ListAssets returns DJTX followed by the assets that [addrs] hold on the
+// X-Chain, in the order found. The node has no API to enumerate every
+// asset of the network, so the assets are discovered from the balances
+// of [addrs].
+func (cc *client) ListAssets(ctx context.Context, addrs ...ids.ShortID) ([]Asset, error) {
+	hrp := constants.GetHRP(cc.networkID)
+	assetIDs := []string{cc.assetID.String()}
+	for _, addr := range addrs {
+		xAddr, err := formatting.FormatAddress("X", hrp, addr[:])
+		if err != nil {
+			return nil, err
+		}
+		balances, err := cc.xc.GetAllBalances(ctx, xAddr, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balances of %s: %w", addr, err)
+		}
+		for _, b := range balances {
+			assetIDs = append(assetIDs, b.AssetID)
+		}
+	}
+
+	seen := make(map[ids.ID]struct{}, len(assetIDs))
+	assets := make([]Asset, 0, len(assetIDs))
+	for _, assetID := range assetIDs {
+		// balances may name an asset by alias (e.g., "DJTX"),
+		// so dedupe on the ID in the description
+		desc, err := cc.xc.GetAssetDescription(ctx, assetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe asset %s: %w", assetID, err)
+		}
+		if _, ok := seen[desc.AssetID]; ok {
+			continue
+		}
+		seen[desc.AssetID] = struct{}{}
+		assets = append(assets, Asset{
+			ID:           desc.AssetID,
+			Name:         desc.Name,
+			Symbol:       desc.Symbol,
+			Denomination: uint8(desc.Denomination),
+		})
+	}
+	zap.L().Debug("listed assets", zap.Int("assets", len(assets)))
+	return assets, nil
+}
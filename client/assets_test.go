@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/formatting"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+	"github.com/lasthyphen/dijetsnodego/utils/rpc"
+	"github.com/lasthyphen/dijetsnodego/vms/avm"
+)
+
+var errUnknownAsset = errors.New("unknown asset")
+
+// assetsClient serves the X-Chain [balances] of each address, and the
+// [descs] of each asset by ID or alias.
+type assetsClient struct {
+	avm.Client
+	balances map[string][]avm.Balance
+	descs    map[string]*avm.GetAssetDescriptionReply
+}
+
+func (c *assetsClient) GetAllBalances(_ context.Context, addr string, _ bool, _ ...rpc.Option) ([]avm.Balance, error) {
+	bs, ok := c.balances[addr]
+	if !ok {
+		return nil, fmt.Errorf("no balances for %s", addr)
+	}
+	return bs, nil
+}
+
+func (c *assetsClient) GetAssetDescription(_ context.Context, assetID string, _ ...rpc.Option) (*avm.GetAssetDescriptionReply, error) {
+	desc, ok := c.descs[assetID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownAsset, assetID)
+	}
+	return desc, nil
+}
+
+func TestListAssets(t *testing.T) {
+	t.Parallel()
+
+	djtxID, fooID, barID := ids.ID{1}, ids.ID{2}, ids.ID{3}
+	addr1, addr2, addr3 := ids.ShortID{1}, ids.ShortID{2}, ids.ShortID{3}
+	xAddr := func(addr ids.ShortID) string {
+		s, err := formatting.FormatAddress("X", constants.GetHRP(constants.LocalID), addr[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	desc := func(id ids.ID, symbol string) *avm.GetAssetDescriptionReply {
+		return &avm.GetAssetDescriptionReply{
+			FormattedAssetID: avm.FormattedAssetID{AssetID: id},
+			Name:             symbol + " token",
+			Symbol:           symbol,
+			Denomination:     json.Uint8(9),
+		}
+	}
+	asset := func(id ids.ID, symbol string) Asset {
+		return Asset{ID: id, Name: symbol + " token", Symbol: symbol, Denomination: 9}
+	}
+	xc := &assetsClient{
+		balances: map[string][]avm.Balance{
+			// DJTX by alias
+			xAddr(addr1): {{AssetID: "DJTX", Balance: 1}, {AssetID: fooID.String(), Balance: 1}},
+			xAddr(addr2): {{AssetID: barID.String(), Balance: 1}, {AssetID: fooID.String(), Balance: 1}},
+			xAddr(addr3): {{AssetID: ids.ID{9}.String(), Balance: 1}},
+		},
+		descs: map[string]*avm.GetAssetDescriptionReply{
+			djtxID.String(): desc(djtxID, "DJTX"),
+			"DJTX":          desc(djtxID, "DJTX"),
+			fooID.String():  desc(fooID, "FOO"),
+			barID.String():  desc(barID, "BAR"),
+		},
+	}
+
+	tt := []struct {
+		name        string
+		addrs       []ids.ShortID
+		expected    []Asset
+		expectedErr error
+	}{
+		{name: "no addresses", expected: []Asset{asset(djtxID, "DJTX")}},
+		{name: "alias", addrs: []ids.ShortID{addr1}, expected: []Asset{asset(djtxID, "DJTX"), asset(fooID, "FOO")}},
+		{
+			name:     "in order found",
+			addrs:    []ids.ShortID{addr1, addr2},
+			expected: []Asset{asset(djtxID, "DJTX"), asset(fooID, "FOO"), asset(barID, "BAR")},
+		},
+		{name: "unknown asset", addrs: []ids.ShortID{addr3}, expectedErr: errUnknownAsset},
+	}
+	for i, tv := range tt {
+		cc := &client{networkID: constants.LocalID, assetID: djtxID, xc: xc}
+		assets, err := cc.ListAssets(context.Background(), tv.addrs...)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+		if !reflect.DeepEqual(assets, tv.expected) {
+			t.Fatalf("#%d(%s): unexpected assets %+v, expected %+v", i, tv.name, assets, tv.expected)
+		}
+	}
+
+	// failing to get the balances of an address fails the listing
+	cc := &client{networkID: constants.LocalID, assetID: djtxID, xc: xc}
+	if _, err := cc.ListAssets(context.Background(), ids.ShortID{4}); err == nil {
+		t.Fatal("expected error for an address without balances")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"go.uber.org/zap"
+)
+
+// AuditEntry is a line of the audit log, recorded for every tx issued.
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	// Address is the formatted P-Chain address of the key that signed
+	// the tx, empty for raw txs signed elsewhere.
+	Address string `json:"address,omitempty"`
+	TxID    string `json:"txId"`
+	// Fee is the nDJTX burned, 0 for raw txs built elsewhere.
+	Fee uint64 `json:"fee"`
+	// GenesisHash is the "GenesisHash" of the VM genesis, for blockchains.
+	GenesisHash string `json:"genesisHash,omitempty"`
+	// Result is "issued" or "failed", with the error in [Error].
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// To append an "AuditEntry" (as a JSON line) to the file at [path] for
+// every tx issued, whether the node accepted it or not. Unlike the logs,
+// it's written regardless of the log level, as a durable record of every
+// fund-moving action.
+func WithAuditLog(path string) OpOption {
+	return func(op *Op) {
+		op.auditLogPath = path
+	}
+}
+
+// audit records the result of issuing [pTx] to the audit log, if set.
+// The tx may already be issued, so a failure to write the entry is
+// logged rather than failing the operation.
+func (pc *p) audit(ret *Op, pAddr string, pTx *platformvm.Tx, fee uint64, issueErr error) {
+	if ret.auditLogPath == "" {
+		return
+	}
+	entry := AuditEntry{
+		Time:      time.Now().UTC(),
+		Operation: txOperation(pTx.UnsignedTx),
+		Address:   pAddr,
+		TxID:      pTx.ID().String(),
+		Fee:       fee,
+		Result:    "issued",
+	}
+	if chainTx, ok := pTx.UnsignedTx.(*platformvm.UnsignedCreateChainTx); ok {
+		entry.GenesisHash = GenesisHash(chainTx.GenesisData)
+	}
+	if issueErr != nil {
+		entry.Result = "failed"
+		entry.Error = issueErr.Error()
+	}
+	if err := appendAuditEntry(ret.auditLogPath, entry); err != nil {
+		zap.L().Error("failed to write audit log",
+			zap.String("path", ret.auditLogPath),
+			zap.String("txId", entry.TxID),
+			zap.Error(err),
+		)
+	}
+}
+
+func appendAuditEntry(path string, entry AuditEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, fsModeWrite)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	// durable before moving on to the next tx
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// txOperation names the operation of the unsigned tx.
+func txOperation(utx platformvm.UnsignedTx) string {
+	switch utx.(type) {
+	case *platformvm.UnsignedCreateSubnetTx:
+		return OperationCreateSubnet.String()
+	case *platformvm.UnsignedAddValidatorTx:
+		return OperationAddValidator.String()
+	case *platformvm.UnsignedAddSubnetValidatorTx:
+		return OperationAddSubnetValidator.String()
+	case *platformvm.UnsignedCreateChainTx:
+		return OperationCreateBlockchain.String()
+	default:
+		return fmt.Sprintf("%T", utx)
+	}
+}
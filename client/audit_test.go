@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAppendAuditEntry(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	entries := []AuditEntry{
+		{Time: time.Unix(100, 0).UTC(), Operation: "create-subnet", Address: "P-custom1a", TxID: "tx1", Fee: 1000, Result: "issued"},
+		{Time: time.Unix(200, 0).UTC(), Operation: "add-validator", Address: "P-custom1a", TxID: "tx2", Fee: 0, Result: "failed", Error: "insufficient funds"},
+		{Time: time.Unix(300, 0).UTC(), Operation: "create-blockchain", Address: "P-custom1a", TxID: "tx3", Fee: 1000, GenesisHash: GenesisHash([]byte("{}")), Result: "issued"},
+	}
+	for _, e := range entries {
+		if err := appendAuditEntry(path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	read := make([]AuditEntry, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+		read = append(read, e)
+	}
+	if !reflect.DeepEqual(read, entries) {
+		t.Fatalf("unexpected entries %+v, expected %+v", read, entries)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+)
+
+// ValidateChainName returns the blockchain name with surrounding whitespace
+// trimmed, or an error if the node would reject it (e.g., too long).
+func ValidateChainName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) > platformvm.MaxNameLen {
+		return "", fmt.Errorf("%w: %d bytes exceeds the maximum %d", ErrInvalidChainName, len(name), platformvm.MaxNameLen)
+	}
+	// same as the syntactic verification of the node
+	for _, r := range name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsNumber(r) || r == ' ') {
+			return "", fmt.Errorf("%w: %q has illegal character %q (only letters, numbers, and spaces)", ErrInvalidChainName, name, r)
+		}
+	}
+	return name, nil
+}
+
+// To fail with "ErrDuplicateChainName" when the subnet already has a
+// blockchain of the same name. The node accepts duplicate names, but they
+// make the blockchains hard to tell apart.
+func WithUniqueChainName(b bool) OpOption {
+	return func(op *Op) {
+		op.uniqueChainName = b
+	}
+}
+
+// checkChainName checks that no blockchain under [subnetID] is named
+// [chainName], if "WithUniqueChainName" is set.
+func (pc *p) checkChainName(ctx context.Context, ret *Op, subnetID ids.ID, chainName string) error {
+	if !ret.uniqueChainName {
+		return nil
+	}
+	bcs, err := pc.cli.GetBlockchains(ctx)
+	if err != nil {
+		return err
+	}
+	for _, bc := range bcs {
+		if bc.SubnetID == subnetID && bc.Name == chainName {
+			return fmt.Errorf("%w: %q is blockchain %s", ErrDuplicateChainName, chainName, bc.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateChainName(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		expected string
+		err      error
+	}{
+		{name: "spacesvm", expected: "spacesvm"},
+		{name: "  my chain 2\n", expected: "my chain 2"},
+		{name: strings.Repeat("a", 128), expected: strings.Repeat("a", 128)},
+		{name: strings.Repeat("a", 129), err: ErrInvalidChainName},
+		{name: "my-chain", err: ErrInvalidChainName},
+		{name: "chaîne", err: ErrInvalidChainName},
+	}
+	for i, tv := range tt {
+		name, err := ValidateChainName(tv.name)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.err)
+		}
+		if name != tv.expected {
+			t.Fatalf("#%d(%s): unexpected name %q, expected %q", i, tv.name, name, tv.expected)
+		}
+	}
+}
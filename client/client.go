@@ -8,6 +8,7 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/url"
 	"time"
 
@@ -24,12 +25,35 @@ var (
 	ErrEmptyID         = errors.New("empty ID")
 	ErrEmptyURI        = errors.New("empty URI")
 	ErrInvalidInterval = errors.New("invalid interval")
+
+	ErrNetworkIDMismatch = errors.New("network ID mismatch")
+	ErrUnexpectedNetwork = errors.New("unexpected network")
 )
 
 type Config struct {
 	URI          string
 	u            *url.URL
 	PollInterval time.Duration
+	// AssetID is the known DJTX asset ID of the network. If set, the
+	// client skips discovering it from the X-Chain on creation.
+	AssetID *ids.ID
+	// PChainID is the platform chain ID of the network, for custom
+	// deployments whose P-Chain ID differs from the standard one.
+	// Defaults to "constants.PlatformChainID" if unset.
+	PChainID *ids.ID
+	// ExpectedNetwork is the network name (e.g., "mainnet", "tahoe",
+	// "local") or "network-<id>" the node must be on. If set, the
+	// client refuses to be created against any other network.
+	// Empty to trust the network reported by the node.
+	ExpectedNetwork string
+	// StrictVersionCheck fails the client creation if the node version
+	// is outside the range whose tx formats the client builds.
+	// Otherwise, an incompatible version only warns.
+	StrictVersionCheck bool
+	// FaucetURL is the faucet "RequestTestnetFunds" requests funds from.
+	// Defaults to "DefaultFaucetURL" on Tahoe, and required on other
+	// test networks (e.g., a local faucet).
+	FaucetURL string
 }
 
 var _ Client = &client{}
@@ -40,6 +64,17 @@ type Client interface {
 	Info() Info
 	KeyStore() KeyStore
 	P() P
+	// StakingParams returns the primary network staking parameters,
+	// fetched from the node on first use. It falls back to the genesis
+	// config of the network if the node can't be reached.
+	StakingParams(ctx context.Context) StakingParams
+	SupportBundle(ctx context.Context) ([]byte, error)
+	// ListAssets returns DJTX and the X-Chain assets held by [addrs].
+	ListAssets(ctx context.Context, addrs ...ids.ShortID) ([]Asset, error)
+	// RequestTestnetFunds requests test funds for the P-Chain address
+	// [addr] from the faucet. It fails with "ErrFaucetUnavailable" on
+	// mainnet.
+	RequestTestnetFunds(ctx context.Context, addr string) (*FaucetReply, error)
 }
 
 type client struct {
@@ -52,9 +87,10 @@ type client struct {
 	xChainID    ids.ID
 	pChainID    ids.ID
 
-	i *info
-	k *keyStore
-	p *p
+	i  *info
+	k  *keyStore
+	p  *p
+	xc avm.Client
 }
 
 func New(cfg Config) (Client, error) {
@@ -64,6 +100,20 @@ func New(cfg Config) (Client, error) {
 	if cfg.PollInterval == time.Duration(0) {
 		return nil, ErrInvalidInterval
 	}
+	if cfg.AssetID != nil && *cfg.AssetID == ids.Empty {
+		return nil, fmt.Errorf("%w: asset ID", ErrEmptyID)
+	}
+	if cfg.PChainID != nil && *cfg.PChainID == ids.Empty {
+		return nil, fmt.Errorf("%w: P-Chain ID", ErrEmptyID)
+	}
+	expectedNetworkID := uint32(0)
+	if cfg.ExpectedNetwork != "" {
+		var err error
+		expectedNetworkID, err = avago_constants.NetworkID(cfg.ExpectedNetwork)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	u, err := url.Parse(cfg.URI)
 	if err != nil {
@@ -77,6 +127,14 @@ func New(cfg Config) (Client, error) {
 		i:        newInfo(cfg),
 		k:        newKeyStore(cfg),
 	}
+	if cfg.PChainID != nil {
+		cli.pChainID = *cfg.PChainID
+		zap.L().Info("using configured P-Chain id", zap.String("id", cli.pChainID.String()))
+	}
+
+	if err := cli.checkNodeVersion(context.TODO(), cfg.StrictVersionCheck); err != nil {
+		return nil, err
+	}
 
 	zap.L().Info("fetching X-Chain id")
 	xChainID, err := cli.i.Client().GetBlockchainID(context.TODO(), "X")
@@ -93,16 +151,22 @@ func New(cfg Config) (Client, error) {
 		// e.g., https://api.djtx-test.network
 		xChainName = "X"
 	}
-	zap.L().Info("fetching DJTX asset id",
-		zap.String("uri", uriX),
-	)
-	xc := avm.NewClient(uriX, xChainName)
-	djtxDesc, err := xc.GetAssetDescription(context.TODO(), "DJTX")
-	if err != nil {
-		return nil, err
+	cli.xc = avm.NewClient(uriX, xChainName)
+
+	if cfg.AssetID != nil {
+		cli.assetID = *cfg.AssetID
+		zap.L().Info("using configured DJTX asset id", zap.String("id", cli.assetID.String()))
+	} else {
+		zap.L().Info("fetching DJTX asset id",
+			zap.String("uri", uriX),
+		)
+		djtxDesc, err := cli.xc.GetAssetDescription(context.TODO(), "DJTX")
+		if err != nil {
+			return nil, err
+		}
+		cli.assetID = djtxDesc.AssetID
+		zap.L().Info("fetched DJTX asset id", zap.String("id", cli.assetID.String()))
 	}
-	cli.assetID = djtxDesc.AssetID
-	zap.L().Info("fetched DJTX asset id", zap.String("id", cli.assetID.String()))
 
 	zap.L().Info("fetching network information")
 	cli.networkName, err = cli.i.Client().GetNetworkName(context.TODO())
@@ -113,6 +177,20 @@ func New(cfg Config) (Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	// cross-check, in case the node reports an inconsistent network identity
+	reportedNetworkID, err := cli.i.Client().GetNetworkID(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	if reportedNetworkID != cli.networkID {
+		return nil, fmt.Errorf("%w: node reported %d, but network name %q maps to %d",
+			ErrNetworkIDMismatch, reportedNetworkID, cli.networkName, cli.networkID)
+	}
+	if expectedNetworkID != 0 && cli.networkID != expectedNetworkID {
+		return nil, fmt.Errorf("%w: node %q is on %q (%d), but expected %q (%d)",
+			ErrUnexpectedNetwork, redactURI(u), cli.networkName, cli.networkID,
+			avago_constants.NetworkName(expectedNetworkID), expectedNetworkID)
+	}
 	zap.L().Info("fetched network information",
 		zap.Uint32("networkId", cli.networkID),
 		zap.String("networkName", cli.networkName),
@@ -148,3 +226,7 @@ func (cc *client) Info() Info         { return cc.i }
 func (cc *client) KeyStore() KeyStore { return cc.k }
 
 func (cc *client) P() P { return cc.p }
+
+func (cc *client) StakingParams(ctx context.Context) StakingParams {
+	return cc.p.getStakingParams(ctx)
+}
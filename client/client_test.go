@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestNewConfigValidation(t *testing.T) {
+	t.Parallel()
+
+	pChainID, assetID, empty := ids.ID{1}, ids.ID{2}, ids.Empty
+	// nothing listens on the port, so a valid config fails reaching the node
+	const unreachable = "http://127.0.0.1:1"
+	tt := []struct {
+		name        string
+		cfg         Config
+		expectedErr error
+	}{
+		{name: "empty URI", cfg: Config{PollInterval: time.Second}, expectedErr: ErrEmptyURI},
+		{name: "empty poll interval", cfg: Config{URI: unreachable}, expectedErr: ErrInvalidInterval},
+		{name: "empty asset ID", cfg: Config{URI: unreachable, PollInterval: time.Second, AssetID: &empty}, expectedErr: ErrEmptyID},
+		{name: "empty P-Chain ID", cfg: Config{URI: unreachable, PollInterval: time.Second, PChainID: &empty}, expectedErr: ErrEmptyID},
+		{name: "P-Chain ID", cfg: Config{URI: unreachable, PollInterval: time.Second, PChainID: &pChainID}},
+		{name: "P-Chain and asset IDs", cfg: Config{URI: unreachable, PollInterval: time.Second, PChainID: &pChainID, AssetID: &assetID}},
+		{name: "unset P-Chain ID", cfg: Config{URI: unreachable, PollInterval: time.Second}},
+	}
+	for i, tv := range tt {
+		_, err := New(tv.cfg)
+		if tv.expectedErr != nil {
+			if !errors.Is(err, tv.expectedErr) {
+				t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+			}
+			continue
+		}
+		// passed validation, so only failed to reach the node
+		if err == nil || !strings.Contains(err.Error(), "failed to reach node") {
+			t.Fatalf("#%d(%s): unexpected error %v, expected the node to be unreachable", i, tv.name, err)
+		}
+	}
+}
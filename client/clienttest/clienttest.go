@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package clienttest implements fixture builders to test code built
+// on the client without a live node.
+package clienttest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+
+	"github.com/lasthyphen/subnet-cli/internal/codec"
+)
+
+type Op struct {
+	assetID     ids.ID
+	txID        ids.ID
+	outputIndex uint32
+	amount      uint64
+	locktime    uint64
+	threshold   uint32
+	owners      []ids.ShortID
+}
+
+type OpOption func(*Op)
+
+func (op *Op) applyOpts(opts []OpOption) {
+	for _, opt := range opts {
+		opt(op)
+	}
+}
+
+// To set the asset ID of the UTXO (e.g., the DJTX asset ID of the network).
+func WithAssetID(v ids.ID) OpOption {
+	return func(op *Op) {
+		op.assetID = v
+	}
+}
+
+// To set the ID of the tx that produced the UTXO.
+// Defaults to a random ID.
+func WithTxID(v ids.ID) OpOption {
+	return func(op *Op) {
+		op.txID = v
+	}
+}
+
+func WithOutputIndex(v uint32) OpOption {
+	return func(op *Op) {
+		op.outputIndex = v
+	}
+}
+
+// To set the amount of the UTXO. Defaults to 1 DJTX.
+func WithAmount(v uint64) OpOption {
+	return func(op *Op) {
+		op.amount = v
+	}
+}
+
+// To wrap the output in a "platformvm.StakeableLockOut" locked until [v].
+func WithLocktime(v uint64) OpOption {
+	return func(op *Op) {
+		op.locktime = v
+	}
+}
+
+// To set the owners of the output and the number of required signatures.
+func WithOwners(threshold uint32, owners ...ids.ShortID) OpOption {
+	return func(op *Op) {
+		op.threshold = threshold
+		op.owners = owners
+	}
+}
+
+// UTXO builds a secp256k1 UTXO, stakeable-locked if "WithLocktime" is set.
+func UTXO(opts ...OpOption) *djtx.UTXO {
+	ret := &Op{
+		txID:      ids.GenerateTestID(),
+		amount:    1 * units.Djtx,
+		threshold: 1,
+	}
+	ret.applyOpts(opts)
+
+	var out djtx.TransferableOut = &secp256k1fx.TransferOutput{
+		Amt: ret.amount,
+		OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: ret.threshold,
+			Addrs:     ret.owners,
+		},
+	}
+	if ret.locktime > 0 {
+		out = &platformvm.StakeableLockOut{
+			Locktime:        ret.locktime,
+			TransferableOut: out,
+		}
+	}
+	return &djtx.UTXO{
+		UTXOID: djtx.UTXOID{
+			TxID:        ret.txID,
+			OutputIndex: ret.outputIndex,
+		},
+		Asset: djtx.Asset{ID: ret.assetID},
+		Out:   out,
+	}
+}
+
+// UTXOBytes marshals the UTXOs as returned by "GetUTXOs"/"GetAtomicUTXOs".
+func UTXOBytes(utxos ...*djtx.UTXO) ([][]byte, error) {
+	ubs := make([][]byte, len(utxos))
+	for i, utxo := range utxos {
+		ub, err := codec.PCodecManager.Marshal(codec.LatestCodecVersion, utxo)
+		if err != nil {
+			return nil, err
+		}
+		ubs[i] = ub
+	}
+	return ubs, nil
+}
+
+// Tx builds the unsigned tx with empty credentials, initialized with
+// its marshaled bytes as "codec.DecodeTx" does.
+func Tx(utx platformvm.UnsignedTx) (*platformvm.Tx, error) {
+	tx := &platformvm.Tx{UnsignedTx: utx}
+	unsignedBytes, err := codec.MarshalUnsignedTx(utx)
+	if err != nil {
+		return nil, err
+	}
+	signedBytes, err := codec.MarshalTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	tx.Initialize(unsignedBytes, signedBytes)
+	return tx, nil
+}
+
+// Validator builds a validator record as returned by
+// "GetCurrentValidators"/"GetPendingValidators". [weight] is reported
+// as "weight", or as "stakeAmount" for primary network validators.
+func Validator(nodeID ids.ShortID, start time.Time, end time.Time, weight uint64, primary bool) map[string]interface{} {
+	v := map[string]interface{}{
+		"nodeID":    nodeID.PrefixedString(constants.NodeIDPrefix),
+		"startTime": strconv.FormatInt(start.Unix(), 10),
+		"endTime":   strconv.FormatInt(end.Unix(), 10),
+	}
+	if primary {
+		v["stakeAmount"] = strconv.FormatUint(weight, 10)
+	} else {
+		v["weight"] = strconv.FormatUint(weight, 10)
+	}
+	return v
+}
+
+// Validators wraps the records as the "[]interface{}" validator response.
+func Validators(vs ...map[string]interface{}) []interface{} {
+	ret := make([]interface{}, len(vs))
+	for i, v := range vs {
+		ret[i] = v
+	}
+	return ret
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package clienttest_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+
+	"github.com/lasthyphen/subnet-cli/client/clienttest"
+	"github.com/lasthyphen/subnet-cli/internal/codec"
+	internal_djtx "github.com/lasthyphen/subnet-cli/internal/djtx"
+)
+
+func ExampleUTXO() {
+	utxo := clienttest.UTXO(
+		clienttest.WithAmount(5*units.Djtx),
+		clienttest.WithLocktime(1700000000),
+		clienttest.WithOwners(1, ids.ShortID{1}),
+	)
+	ubs, err := clienttest.UTXOBytes(utxo)
+	if err != nil {
+		panic(err)
+	}
+
+	// parse as the client parses the "GetUTXOs" response
+	parsed, err := internal_djtx.ParseUTXO(ubs[0], codec.PCodecManager)
+	if err != nil {
+		panic(err)
+	}
+	out := parsed.Out.(*platformvm.StakeableLockOut)
+	fmt.Println(out.Locktime, out.Amount())
+	// Output: 1700000000 5000000000
+}
+
+func ExampleValidator() {
+	start := time.Unix(1700000000, 0)
+	vs := clienttest.Validators(
+		clienttest.Validator(ids.ShortID{1}, start, start.Add(24*time.Hour), 1000, false),
+	)
+	v := vs[0].(map[string]interface{})
+	fmt.Println(v["startTime"], v["endTime"], v["weight"])
+	// Output: 1700000000 1700086400 1000
+}
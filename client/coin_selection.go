@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"sort"
+
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+)
+
+// CoinSelection is the order in which "stake" burns the unlocked UTXOs.
+// Locked UTXOs are always staked first regardless.
+type CoinSelection uint8
+
+const (
+	// CoinSelectionDefault burns the plain unlocked UTXOs first, and only
+	// then the ones whose stakeable lock has expired, each in the order
+	// they are fetched, so that the formerly locked outputs are left intact
+	// unless needed.
+	CoinSelectionDefault CoinSelection = iota
+	// CoinSelectionPreserveLiquid burns the least liquid UTXOs first: the
+	// ones whose stakeable lock has just expired, then the smallest ones,
+	// so that the large liquid UTXOs are left intact.
+	CoinSelectionPreserveLiquid
+	// CoinSelectionFetchOrder burns the UTXOs in the order they are
+	// fetched, regardless of whether they were locked.
+	CoinSelectionFetchOrder
+)
+
+// To set the order in which the unlocked UTXOs are burned.
+func WithCoinSelection(s CoinSelection) OpOption {
+	return func(op *Op) {
+		op.coinSelection = s
+	}
+}
+
+// burnOrder returns the UTXOs in the order to burn them with [s].
+// It never reorders [utxos] itself.
+func burnOrder(utxos []*djtx.UTXO, s CoinSelection) []*djtx.UTXO {
+	if s == CoinSelectionFetchOrder {
+		return utxos
+	}
+	ordered := make([]*djtx.UTXO, len(utxos))
+	copy(ordered, utxos)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		_, iLocked := ordered[i].Out.(*platformvm.StakeableLockOut)
+		_, jLocked := ordered[j].Out.(*platformvm.StakeableLockOut)
+		if s != CoinSelectionPreserveLiquid {
+			// plain unlocked first, otherwise in fetch order
+			return !iLocked && jLocked
+		}
+		if iLocked != jLocked {
+			return iLocked
+		}
+		return utxoAmount(ordered[i]) < utxoAmount(ordered[j])
+	})
+	return ordered
+}
+
+func utxoAmount(utxo *djtx.UTXO) uint64 {
+	out, ok := utxo.Out.(interface{ Amount() uint64 })
+	if !ok {
+		return 0
+	}
+	return out.Amount()
+}
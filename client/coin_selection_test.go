@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+
+	"github.com/lasthyphen/subnet-cli/client/clienttest"
+)
+
+func TestBurnOrder(t *testing.T) {
+	t.Parallel()
+
+	// formerly locked (expired) and plain unlocked, interleaved
+	utxos := []*djtx.UTXO{
+		clienttest.UTXO(clienttest.WithTxID(ids.ID{1}), clienttest.WithAmount(5*units.Djtx), clienttest.WithLocktime(1)),
+		clienttest.UTXO(clienttest.WithTxID(ids.ID{2}), clienttest.WithAmount(3*units.Djtx)),
+		clienttest.UTXO(clienttest.WithTxID(ids.ID{3}), clienttest.WithAmount(2*units.Djtx), clienttest.WithLocktime(1)),
+		clienttest.UTXO(clienttest.WithTxID(ids.ID{4}), clienttest.WithAmount(units.Djtx)),
+	}
+	tt := []struct {
+		s        CoinSelection
+		expected []ids.ID
+	}{
+		{s: CoinSelectionDefault, expected: []ids.ID{{2}, {4}, {1}, {3}}},
+		{s: CoinSelectionPreserveLiquid, expected: []ids.ID{{3}, {1}, {4}, {2}}},
+		{s: CoinSelectionFetchOrder, expected: []ids.ID{{1}, {2}, {3}, {4}}},
+	}
+	for i, tv := range tt {
+		ordered := burnOrder(utxos, tv.s)
+		txIDs := make([]ids.ID, len(ordered))
+		for j, utxo := range ordered {
+			txIDs[j] = utxo.TxID
+		}
+		if !reflect.DeepEqual(txIDs, tv.expected) {
+			t.Fatalf("#%d: unexpected order %v, expected %v", i, txIDs, tv.expected)
+		}
+	}
+	if utxos[0].TxID != (ids.ID{1}) {
+		t.Fatal("unexpected reorder of the given UTXOs")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/subnet-cli/internal/key"
+)
+
+func (pc *p) CrossChainBalances(ctx context.Context, k key.Key) (pChain uint64, xChainImportable uint64, err error) {
+	pChain, err = pc.Balance(ctx, k)
+	if err != nil {
+		return 0, 0, err
+	}
+	xChainImportable, err = pc.ImportableBalance(ctx, k)
+	if err != nil {
+		return 0, 0, err
+	}
+	return pChain, xChainImportable, nil
+}
+
+func (pc *p) ImportableBalance(ctx context.Context, k key.Key) (uint64, error) {
+	ubs, err := pc.fetchAtomicUTXOs(ctx, k.P(), "X")
+	if err != nil {
+		return 0, err
+	}
+	utxos, err := parseUTXOs(ubs)
+	if err != nil {
+		return 0, err
+	}
+	importable := uint64(0)
+	for _, utxo := range utxos {
+		// assume "AssetID" is set to "DJTX" asset ID
+		if utxo.AssetID() != pc.assetID {
+			continue
+		}
+		out, ok := utxo.Out.(djtx.TransferableOut)
+		if !ok {
+			continue
+		}
+		importable, err = AddAmounts(importable, out.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return importable, nil
+}
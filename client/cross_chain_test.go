@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lasthyphen/dijetsnodego/api"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+	"github.com/lasthyphen/dijetsnodego/utils/rpc"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+
+	"github.com/lasthyphen/subnet-cli/client/clienttest"
+)
+
+// crossChainClient serves [pChain] as the P-Chain balance of any address,
+// and [exported] as its UTXOs exported from the X-Chain.
+type crossChainClient struct {
+	platformvm.Client
+	pChain   uint64
+	exported [][]byte
+}
+
+func (c *crossChainClient) GetBalance(context.Context, []string, ...rpc.Option) (*platformvm.GetBalanceResponse, error) {
+	return &platformvm.GetBalanceResponse{Balance: json.Uint64(c.pChain)}, nil
+}
+
+func (c *crossChainClient) GetAtomicUTXOs(_ context.Context, _ []string, sourceChain string, _ uint32, _ string, _ string, _ ...rpc.Option) ([][]byte, api.Index, error) {
+	if sourceChain != "X" {
+		return nil, api.Index{}, nil
+	}
+	return c.exported, api.Index{}, nil
+}
+
+func TestCrossChainBalances(t *testing.T) {
+	t.Parallel()
+
+	k, pc := newTestStaker(t)
+	exported, err := clienttest.UTXOBytes(
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{2}),
+			clienttest.WithAmount(3*units.Djtx),
+			clienttest.WithOwners(1, k.Address()),
+		),
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{3}),
+			clienttest.WithAmount(units.Djtx),
+			clienttest.WithOwners(1, k.Address()),
+		),
+		// not DJTX, so not counted
+		clienttest.UTXO(
+			clienttest.WithAssetID(ids.ID{9}),
+			clienttest.WithTxID(ids.ID{4}),
+			clienttest.WithAmount(units.Djtx),
+			clienttest.WithOwners(1, k.Address()),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc.cli = &crossChainClient{pChain: 2 * units.Djtx, exported: exported}
+
+	pChain, importable, err := pc.CrossChainBalances(context.Background(), k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pChain != 2*units.Djtx {
+		t.Fatalf("unexpected P-Chain balance %d, expected %d", pChain, 2*units.Djtx)
+	}
+	if importable != 4*units.Djtx {
+		t.Fatalf("unexpected importable balance %d, expected %d", importable, 4*units.Djtx)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	avago_constants "github.com/lasthyphen/dijetsnodego/utils/constants"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrFaucetUnavailable = errors.New("faucet unavailable")
+	ErrFaucetRateLimited = errors.New("faucet rate limited")
+	ErrFaucetFailed      = errors.New("faucet request failed")
+)
+
+// DefaultFaucetURL is the faucet of the Tahoe test network.
+const DefaultFaucetURL = "https://faucet.avax-test.network"
+
+// maxFaucetReplySize bounds the faucet reply read, as it's an external
+// service.
+const maxFaucetReplySize = 64 * 1024
+
+// FaucetReply is the result of requesting test funds.
+type FaucetReply struct {
+	// TxID is the ID of the tx that sends the funds, if reported.
+	TxID    string `json:"txHash"`
+	Message string `json:"message"`
+}
+
+// faucetURL returns the faucet of the network, or an error if the network
+// has none (e.g., mainnet, or a local network with no "Config.FaucetURL").
+func (cc *client) faucetURL() (string, error) {
+	switch {
+	case cc.networkID == avago_constants.MainnetID:
+		return "", fmt.Errorf("%w: no faucet on %s", ErrFaucetUnavailable, cc.networkName)
+	case cc.cfg.FaucetURL != "":
+		return cc.cfg.FaucetURL, nil
+	case cc.networkID == avago_constants.TahoeID:
+		return DefaultFaucetURL, nil
+	default:
+		return "", fmt.Errorf("%w: no faucet URL configured for %s", ErrFaucetUnavailable, cc.networkName)
+	}
+}
+
+// RequestTestnetFunds requests test funds for the P-Chain address [addr]
+// from the faucet of the network (ref. "Config.FaucetURL"). It never
+// retries: once rate limited, it fails with "ErrFaucetRateLimited" and
+// the time to wait if the faucet reports it.
+func (cc *client) RequestTestnetFunds(ctx context.Context, addr string) (*FaucetReply, error) {
+	if _, err := ParsePAddress(addr); err != nil {
+		return nil, err
+	}
+	faucetURL, err := cc.faucetURL()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(map[string]string{
+		"address": addr,
+		"chain":   "P",
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(faucetURL, "/")+"/api/sendToken", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	zap.L().Info("requesting test funds",
+		zap.String("faucet", faucetURL),
+		zap.String("address", addr),
+	)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	rb, err := io.ReadAll(io.LimitReader(resp.Body, maxFaucetReplySize))
+	if err != nil {
+		return nil, err
+	}
+	reply := &FaucetReply{}
+	if err := json.Unmarshal(rb, reply); err != nil {
+		// e.g., an HTML error page
+		reply.Message = strings.TrimSpace(string(rb))
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return nil, fmt.Errorf("%w: retry in %v (%s)", ErrFaucetRateLimited, wait, reply.Message)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrFaucetRateLimited, reply.Message)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%w: status %d (%s)", ErrFaucetFailed, resp.StatusCode, reply.Message)
+	}
+	zap.L().Info("requested test funds",
+		zap.String("address", addr),
+		zap.String("txId", reply.TxID),
+	)
+	return reply, nil
+}
+
+// retryAfter parses the "Retry-After" header, in seconds or as a date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t).Round(time.Second), true
+	}
+	return 0, false
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	avago_constants "github.com/lasthyphen/dijetsnodego/utils/constants"
+)
+
+func TestRequestTestnetFunds(t *testing.T) {
+	t.Parallel()
+
+	addr := "P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p"
+	// rate limited after the first request
+	requests := uint32(0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || r.URL.Path != "/api/sendToken" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req["address"] != addr {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if atomic.AddUint32(&requests, 1) > 1 {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"too many requests"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"message":"sent","txHash":"tx1"}`))
+	}))
+	defer srv.Close()
+
+	tt := []struct {
+		networkID uint32
+		faucetURL string
+		txID      string
+		err       error
+	}{
+		{networkID: avago_constants.LocalID, faucetURL: srv.URL, txID: "tx1"},
+		{networkID: avago_constants.LocalID, faucetURL: srv.URL, err: ErrFaucetRateLimited},
+		{networkID: avago_constants.LocalID, err: ErrFaucetUnavailable},
+		{networkID: avago_constants.MainnetID, faucetURL: srv.URL, err: ErrFaucetUnavailable},
+	}
+	for i, tv := range tt {
+		cc := &client{cfg: Config{FaucetURL: tv.faucetURL}, networkID: tv.networkID}
+		reply, err := cc.RequestTestnetFunds(context.Background(), addr)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d: unexpected error %v, expected %v", i, err, tv.err)
+		}
+		if err == nil && reply.TxID != tv.txID {
+			t.Fatalf("#%d: unexpected tx %q, expected %q", i, reply.TxID, tv.txID)
+		}
+	}
+}
@@ -4,11 +4,23 @@
 package client
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
 	api_info "github.com/lasthyphen/dijetsnodego/api/info"
+	"github.com/lasthyphen/dijetsnodego/ids"
 )
 
+var ErrGetVMsUnsupported = errors.New("node doesn't list its VMs")
+
 type Info interface {
 	Client() api_info.Client
+	// GetVMs returns the VMs installed on the node, by VM ID to aliases.
+	// It fails with "ErrGetVMsUnsupported" if the node doesn't expose the
+	// endpoint (e.g., older versions).
+	GetVMs(ctx context.Context) (map[ids.ID][]string, error)
 }
 
 type info struct {
@@ -29,3 +41,29 @@ func newInfo(cfg Config) *info {
 }
 
 func (i *info) Client() api_info.Client { return i.cli }
+
+func (i *info) GetVMs(ctx context.Context) (map[ids.ID][]string, error) {
+	return getVMs(ctx, i.cli)
+}
+
+// unsupportedMessages are the substrings of the errors of a node that
+// doesn't serve the method.
+var unsupportedMessages = []string{
+	"can't find method",
+	"method not found",
+	"received status code: 404",
+}
+
+func getVMs(ctx context.Context, cli api_info.Client) (map[ids.ID][]string, error) {
+	vms, err := cli.GetVMs(ctx)
+	if err != nil {
+		msg := strings.ToLower(err.Error())
+		for _, m := range unsupportedMessages {
+			if strings.Contains(msg, m) {
+				return nil, fmt.Errorf("%w (%v)", ErrGetVMsUnsupported, err)
+			}
+		}
+		return nil, err
+	}
+	return vms, nil
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/formatting"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/subnet-cli/internal/codec"
+)
+
+var ErrInvalidManifest = errors.New("invalid manifest")
+
+// Manifest is the intended state of a subnet deployment.
+type Manifest struct {
+	SubnetID ids.ID `json:"subnetId"`
+	// expected control keys of the subnet (e.g., "P-custom1...")
+	Threshold uint32   `json:"threshold"`
+	Owners    []string `json:"owners"`
+
+	Blockchains []ManifestBlockchain `json:"blockchains"`
+
+	// expected active subnet validators (e.g., "NodeID-...")
+	Validators []string `json:"validators"`
+}
+
+type ManifestBlockchain struct {
+	ID   ids.ID `json:"id"`
+	Name string `json:"name"`
+	VMID ids.ID `json:"vmId"`
+	// hex-encoded SHA256 of the VM genesis bytes
+	GenesisHash string `json:"genesisHash"`
+}
+
+// GenesisHash returns the hex-encoded SHA256 of [vmGenesis], to check the
+// genesis deployed on-chain is the one reviewed (e.g., in the manifest).
+func GenesisHash(vmGenesis []byte) string {
+	return hex.EncodeToString(hashing.ComputeHash256(vmGenesis))
+}
+
+// LoadManifest loads the deploy manifest in JSON.
+func LoadManifest(path string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := new(Manifest)
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+	return m, nil
+}
+
+// VerifyReport lists how the on-chain state drifted from the manifest.
+type VerifyReport struct {
+	Drifts []string
+}
+
+// OK returns true if the on-chain state matches the manifest.
+func (r *VerifyReport) OK() bool { return len(r.Drifts) == 0 }
+
+func (r *VerifyReport) drift(format string, args ...interface{}) {
+	r.Drifts = append(r.Drifts, fmt.Sprintf(format, args...))
+}
+
+func (pc *p) Verify(ctx context.Context, m *Manifest) (*VerifyReport, error) {
+	if m.SubnetID == ids.Empty {
+		return nil, fmt.Errorf("%w: empty subnet ID", ErrInvalidManifest)
+	}
+	owners := make([]ids.ShortID, len(m.Owners))
+	for i, addr := range m.Owners {
+		_, _, b, err := formatting.ParseAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: owner %q (%v)", ErrInvalidManifest, addr, err)
+		}
+		owners[i], err = ids.ToShortID(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	nodeIDs := make([]ids.ShortID, len(m.Validators))
+	for i, s := range m.Validators {
+		nodeID, err := ParseNodeID(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: validator %q (%v)", ErrInvalidManifest, s, err)
+		}
+		nodeIDs[i] = nodeID
+	}
+
+	r := &VerifyReport{Drifts: make([]string, 0)}
+
+	ss, err := pc.cli.GetSubnets(ctx, []ids.ID{m.SubnetID})
+	if err != nil {
+		return nil, err
+	}
+	if len(ss) != 1 || ss[0].ID != m.SubnetID {
+		// nothing else can exist without the subnet
+		r.drift("subnet %s not found", m.SubnetID)
+		return r, nil
+	}
+	if len(owners) > 0 {
+		err = pc.VerifySubnetOwners(ctx, m.SubnetID, m.Threshold, owners)
+		if errors.Is(err, ErrSubnetOwnersMismatch) {
+			r.drift("%v", err)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(m.Blockchains) > 0 {
+		if err := pc.verifyBlockchains(ctx, m, r); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(nodeIDs) > 0 {
+		vs, err := pc.GetSubnetValidators(ctx, m.SubnetID)
+		if err != nil {
+			return nil, err
+		}
+		for _, nodeID := range nodeIDs {
+			if _, ok := vs[nodeID]; !ok {
+				r.drift("validator %s not active on subnet %s", FormatNodeID(nodeID), m.SubnetID)
+			}
+		}
+	}
+	return r, nil
+}
+
+func (pc *p) verifyBlockchains(ctx context.Context, m *Manifest, r *VerifyReport) error {
+	bcs, err := pc.cli.GetBlockchains(ctx)
+	if err != nil {
+		return err
+	}
+	for _, expected := range m.Blockchains {
+		found := false
+		for _, bc := range bcs {
+			if bc.ID != expected.ID {
+				continue
+			}
+			found = true
+			if bc.SubnetID != m.SubnetID {
+				r.drift("blockchain %s subnet %s, expected %s", bc.ID, bc.SubnetID, m.SubnetID)
+			}
+			if expected.Name != "" && bc.Name != expected.Name {
+				r.drift("blockchain %s name %q, expected %q", bc.ID, bc.Name, expected.Name)
+			}
+			if expected.VMID != ids.Empty && bc.VMID != expected.VMID {
+				r.drift("blockchain %s VM ID %s, expected %s", bc.ID, bc.VMID, expected.VMID)
+			}
+			break
+		}
+		if !found {
+			r.drift("blockchain %s not found", expected.ID)
+			continue
+		}
+		if expected.GenesisHash == "" {
+			continue
+		}
+
+		h, err := pc.blockchainGenesisHash(ctx, expected.ID)
+		if err != nil {
+			return err
+		}
+		if h != expected.GenesisHash {
+			r.drift("blockchain %s genesis hash %s, expected %s", expected.ID, h, expected.GenesisHash)
+		}
+	}
+	return nil
+}
+
+// blockchainGenesisHash returns the "GenesisHash" of the VM genesis the
+// blockchain was created with.
+func (pc *p) blockchainGenesisHash(ctx context.Context, blkChainID ids.ID) (string, error) {
+	tb, err := pc.getTx(ctx, blkChainID)
+	if err != nil {
+		return "", err
+	}
+	tx, _, err := codec.DecodeTx(tb)
+	if err != nil {
+		return "", err
+	}
+	chainTx, ok := tx.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrWrongTxType, tx.UnsignedTx)
+	}
+	return GenesisHash(chainTx.GenesisData), nil
+}
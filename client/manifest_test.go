@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/formatting"
+	"github.com/lasthyphen/dijetsnodego/utils/rpc"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+
+	"github.com/lasthyphen/subnet-cli/client/clienttest"
+)
+
+// verifyClient serves the on-chain state of a subnet deployment: the
+// [subnets], their [blockchains], the [txs] that created them, and the
+// current subnet [validators].
+type verifyClient struct {
+	platformvm.Client
+	subnets     []platformvm.ClientSubnet
+	blockchains []platformvm.APIBlockchain
+	txs         map[ids.ID][]byte
+	validators  []interface{}
+}
+
+func (c *verifyClient) GetSubnets(context.Context, []ids.ID, ...rpc.Option) ([]platformvm.ClientSubnet, error) {
+	return c.subnets, nil
+}
+
+func (c *verifyClient) GetBlockchains(context.Context, ...rpc.Option) ([]platformvm.APIBlockchain, error) {
+	return c.blockchains, nil
+}
+
+func (c *verifyClient) GetTx(_ context.Context, txID ids.ID, _ ...rpc.Option) ([]byte, error) {
+	tb, ok := c.txs[txID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return tb, nil
+}
+
+func (c *verifyClient) GetCurrentValidators(context.Context, ids.ID, []ids.ShortID, ...rpc.Option) ([]interface{}, error) {
+	return c.validators, nil
+}
+
+func TestVerifyDrift(t *testing.T) {
+	t.Parallel()
+
+	k, pc := newTestStaker(t)
+	subnetID, blkChainID, vmID, nodeID := ids.ID{10}, ids.ID{11}, ids.ID{12}, ids.ShortID{13}
+	vmGenesis := []byte("genesis")
+	subnetTx, err := clienttest.Tx(&platformvm.UnsignedCreateSubnetTx{
+		Owner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{k.Address()}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainTx, err := clienttest.Tx(&platformvm.UnsignedCreateChainTx{
+		SubnetID:    subnetID,
+		ChainName:   "test",
+		VMID:        vmID,
+		GenesisData: vmGenesis,
+		SubnetAuth:  &secp256k1fx.Input{SigIndices: []uint32{0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := formatting.FormatAddress("P", constants.GetHRP(pc.networkID), k.Address().Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := func() *Manifest {
+		return &Manifest{
+			SubnetID:  subnetID,
+			Threshold: 1,
+			Owners:    []string{owner},
+			Blockchains: []ManifestBlockchain{
+				{ID: blkChainID, Name: "test", VMID: vmID, GenesisHash: GenesisHash(vmGenesis)},
+			},
+			Validators: []string{FormatNodeID(nodeID)},
+		}
+	}
+	tt := []struct {
+		name           string
+		modify         func(m *Manifest, cli *verifyClient)
+		expectedDrifts int
+		expectedErr    error
+	}{
+		{name: "matches", modify: func(*Manifest, *verifyClient) {}},
+		{
+			name:           "subnet not found",
+			modify:         func(_ *Manifest, cli *verifyClient) { cli.subnets = nil },
+			expectedDrifts: 1,
+		},
+		{
+			name:           "threshold",
+			modify:         func(m *Manifest, _ *verifyClient) { m.Threshold = 2 },
+			expectedDrifts: 1,
+		},
+		{
+			name:           "blockchain not found",
+			modify:         func(m *Manifest, _ *verifyClient) { m.Blockchains[0].ID = ids.ID{99} },
+			expectedDrifts: 1,
+		},
+		{
+			name:           "blockchain subnet",
+			modify:         func(_ *Manifest, cli *verifyClient) { cli.blockchains[0].SubnetID = ids.ID{99} },
+			expectedDrifts: 1,
+		},
+		{
+			name:           "blockchain name",
+			modify:         func(m *Manifest, _ *verifyClient) { m.Blockchains[0].Name = "other" },
+			expectedDrifts: 1,
+		},
+		{
+			name:           "blockchain VM ID",
+			modify:         func(m *Manifest, _ *verifyClient) { m.Blockchains[0].VMID = ids.ID{99} },
+			expectedDrifts: 1,
+		},
+		{
+			name:           "genesis hash",
+			modify:         func(m *Manifest, _ *verifyClient) { m.Blockchains[0].GenesisHash = GenesisHash([]byte("other")) },
+			expectedDrifts: 1,
+		},
+		{
+			name:           "validator not active",
+			modify:         func(_ *Manifest, cli *verifyClient) { cli.validators = nil },
+			expectedDrifts: 1,
+		},
+		{
+			name: "several",
+			modify: func(m *Manifest, cli *verifyClient) {
+				m.Blockchains[0].Name = "other"
+				cli.validators = nil
+			},
+			expectedDrifts: 2,
+		},
+		{
+			name:        "empty subnet ID",
+			modify:      func(m *Manifest, _ *verifyClient) { m.SubnetID = ids.Empty },
+			expectedErr: ErrInvalidManifest,
+		},
+		{
+			name:        "invalid owner",
+			modify:      func(m *Manifest, _ *verifyClient) { m.Owners = []string{"invalid"} },
+			expectedErr: ErrInvalidManifest,
+		},
+		{
+			name:        "invalid validator",
+			modify:      func(m *Manifest, _ *verifyClient) { m.Validators = []string{"invalid"} },
+			expectedErr: ErrInvalidManifest,
+		},
+	}
+	for i, tv := range tt {
+		m := manifest()
+		cli := &verifyClient{
+			subnets: []platformvm.ClientSubnet{{ID: subnetID}},
+			blockchains: []platformvm.APIBlockchain{
+				{ID: blkChainID, Name: "test", SubnetID: subnetID, VMID: vmID},
+			},
+			txs: map[ids.ID][]byte{
+				subnetID:   subnetTx.Bytes(),
+				blkChainID: chainTx.Bytes(),
+			},
+			validators: []interface{}{
+				map[string]interface{}{"nodeID": FormatNodeID(nodeID), "weight": "1000"},
+			},
+		}
+		tv.modify(m, cli)
+		pc.cli = cli
+
+		r, err := pc.Verify(context.Background(), m)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+		if err != nil {
+			continue
+		}
+		if len(r.Drifts) != tv.expectedDrifts {
+			t.Fatalf("#%d(%s): unexpected drifts %q, expected %d", i, tv.name, r.Drifts, tv.expectedDrifts)
+		}
+		if r.OK() != (tv.expectedDrifts == 0) {
+			t.Fatalf("#%d(%s): unexpected OK %v", i, tv.name, r.OK())
+		}
+	}
+}
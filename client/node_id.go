@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+)
+
+var ErrInvalidNodeID = errors.New("invalid node ID")
+
+// ParseNodeID parses the node ID of any of the common forms:
+// "NodeID-" prefixed CB58 (as reported by the node), bare CB58,
+// or hex (with or without "0x") of the raw 20 bytes.
+func ParseNodeID(s string) (ids.ShortID, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return ids.ShortEmpty, fmt.Errorf("%w: empty", ErrInvalidNodeID)
+	case strings.HasPrefix(s, constants.NodeIDPrefix):
+		nodeID, err := ids.ShortFromPrefixedString(s, constants.NodeIDPrefix)
+		if err != nil {
+			return ids.ShortEmpty, fmt.Errorf("%w: %q (%v)", ErrInvalidNodeID, s, err)
+		}
+		return nodeID, nil
+	}
+
+	h := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(h) == 2*len(ids.ShortEmpty) {
+		if b, err := hex.DecodeString(h); err == nil {
+			return ids.ToShortID(b)
+		}
+	}
+	nodeID, err := ids.ShortFromString(s)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("%w: %q (%v)", ErrInvalidNodeID, s, err)
+	}
+	return nodeID, nil
+}
+
+// FormatNodeID returns the "NodeID-" prefixed form of the node ID,
+// as reported by the node and accepted by "ParseNodeID".
+func FormatNodeID(nodeID ids.ShortID) string {
+	return nodeID.PrefixedString(constants.NodeIDPrefix)
+}
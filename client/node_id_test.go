@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestParseNodeID(t *testing.T) {
+	t.Parallel()
+
+	nodeID := ids.ShortID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	tt := []struct {
+		name   string
+		s      string
+		expErr error
+	}{
+		{name: "prefixed", s: FormatNodeID(nodeID)},
+		{name: "CB58", s: nodeID.String()},
+		{name: "hex", s: hex.EncodeToString(nodeID[:])},
+		{name: "0x hex", s: " 0x" + hex.EncodeToString(nodeID[:]) + "\n"},
+		{name: "empty", s: "", expErr: ErrInvalidNodeID},
+		{name: "invalid prefixed", s: "NodeID-invalid", expErr: ErrInvalidNodeID},
+		{name: "invalid", s: "invalid", expErr: ErrInvalidNodeID},
+	}
+	for i, tv := range tt {
+		parsed, err := ParseNodeID(tv.s)
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expErr)
+		}
+		if tv.expErr == nil && parsed != nodeID {
+			t.Fatalf("#%d(%s): unexpected node ID %s, expected %s", i, tv.name, parsed, nodeID)
+		}
+	}
+}
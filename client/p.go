@@ -4,16 +4,22 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	api_info "github.com/lasthyphen/dijetsnodego/api/info"
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/snow"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/formatting"
 	"github.com/lasthyphen/dijetsnodego/utils/math"
 	"github.com/lasthyphen/dijetsnodego/utils/units"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
@@ -23,8 +29,10 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
 	internal_djtx "github.com/lasthyphen/subnet-cli/internal/djtx"
 	"github.com/lasthyphen/subnet-cli/internal/codec"
+	internal_genesis "github.com/lasthyphen/subnet-cli/internal/genesis"
 	"github.com/lasthyphen/subnet-cli/internal/key"
 	internal_platformvm "github.com/lasthyphen/subnet-cli/internal/platformvm"
+	"github.com/lasthyphen/subnet-cli/internal/poll"
 	"go.uber.org/zap"
 )
 
@@ -32,6 +40,7 @@ var (
 	ErrInsufficientBalanceForGasFee      = errors.New("insufficient balance for gas")
 	ErrInsufficientBalanceForStakeAmount = errors.New("insufficient balance for stake amount")
 	ErrUnexpectedSubnetID                = errors.New("unexpected subnet ID")
+	ErrUnexpectedTxID                    = errors.New("unexpected tx ID")
 
 	ErrEmptyValidator              = errors.New("empty validator set")
 	ErrAlreadyValidator            = errors.New("already validator")
@@ -40,6 +49,29 @@ var (
 	ErrInvalidSubnetValidatePeriod = errors.New("invalid subnet validate period")
 	ErrInvalidValidatorData        = errors.New("invalid validator data")
 	ErrValidatorNotFound           = errors.New("validator not found")
+	ErrInvalidRewardAddress        = errors.New("invalid reward address")
+	ErrTxTooLarge                  = errors.New("tx too large")
+	ErrInvalidDelegationFee        = errors.New("invalid delegation fee")
+	ErrDelegationFeeTooLow         = errors.New("delegation fee too low")
+	ErrHeightQueryUnsupported      = errors.New("height-qualified utxo query unsupported")
+	ErrSubnetOwnersMismatch        = errors.New("subnet owners mismatch")
+	ErrNotElasticSubnet            = errors.New("not an elastic subnet")
+	ErrInvalidOwnerLocktime        = errors.New("invalid owner locktime")
+	ErrInvalidStakeAmount          = errors.New("invalid stake amount")
+	ErrInvalidValidatePeriod       = errors.New("invalid validate period")
+	ErrBlockchainNotFound          = errors.New("blockchain not found")
+	ErrAuthSignerNotOwner          = errors.New("auth signer not among subnet owners")
+	ErrAddressRequired             = errors.New("address required")
+	ErrCantDeriveAddress           = errors.New("can't derive address")
+	ErrVMNotAvailable              = errors.New("VM not available on this node")
+	ErrValidatorDisconnected       = errors.New("validator disconnected")
+	ErrAmountOverflow              = errors.New("amount overflow")
+	ErrCannotCancelValidator       = errors.New("validator can't be canceled")
+	ErrInvalidChainName            = errors.New("invalid chain name")
+	ErrDuplicateChainName          = errors.New("duplicate chain name")
+	ErrTooManyInputs               = errors.New("too many inputs")
+	ErrUnknownSubnetType           = errors.New("unknown subnet type")
+	ErrNegativeCount               = errors.New("negative count")
 
 	// ref. "vms.platformvm".
 	ErrWrongTxType   = errors.New("wrong transaction type")
@@ -51,11 +83,58 @@ type P interface {
 	Client() platformvm.Client
 	Checker() internal_platformvm.Checker
 	Balance(ctx context.Context, key key.Key) (uint64, error)
+	// SpendableBalance returns the balance of the key that can be spent
+	// at [at], excluding the outputs that are still locked by then.
+	// Unlike "Balance", it only counts what the key can sign for.
+	SpendableBalance(ctx context.Context, key key.Key, at time.Time) (uint64, error)
+	// HasBalance returns true if the key can spend at least [atLeast] DJTX
+	// now, excluding the stakeable-locked outputs as "SpendableBalance".
+	// It pages through the UTXOs and returns as soon as the threshold
+	// is met, so it's cheaper than "Balance" for large wallets.
+	HasBalance(ctx context.Context, key key.Key, atLeast uint64) (bool, error)
+	// CreateSubnet creates a subnet controlled by the key, and returns
+	// its owner as read back from the accepted tx (as built in dry mode).
 	CreateSubnet(
 		ctx context.Context,
 		key key.Key,
 		opts ...OpOption,
-	) (subnetID ids.ID, took time.Duration, err error)
+	) (subnetID ids.ID, owner *SubnetOwner, took time.Duration, err error)
+	// ReserveSubnet computes the ID of the subnet that "CreateSubnet" would
+	// create, and returns the UTXOs it's computed from, without issuing.
+	// Creating the subnet with "WithUTXOs(utxos)" and
+	// "WithExpectedSubnetID(subnetID)" (and the same other options) then
+	// yields the same ID, or fails before issuing if it can't.
+	ReserveSubnet(
+		ctx context.Context,
+		key key.Key,
+		opts ...OpOption,
+	) (subnetID ids.ID, utxos []*djtx.UTXO, err error)
+	// GetSubnetCreationTx fetches and decodes the tx that created the
+	// subnet, to audit its origin and owners without issuing any tx.
+	// Fails with "ErrWrongTxType" if [subnetID] is not a subnet.
+	GetSubnetCreationTx(ctx context.Context, subnetID ids.ID) (*platformvm.UnsignedCreateSubnetTx, error)
+	// SubnetValidatorCapacity returns the current number of validators of
+	// the elastic subnet and its maximum, as many as the subnet supply can
+	// stake at the minimum validator stake. Fails with "ErrNotElasticSubnet"
+	// for permissioned subnets without a cap.
+	SubnetValidatorCapacity(ctx context.Context, subnetID ids.ID) (current int, max int, err error)
+	// GetStakingAssetID returns the asset staked on the subnet, which is
+	// DJTX for the primary network. Fails with "ErrNotElasticSubnet" if
+	// the subnet has not been transformed to stake its own asset.
+	GetStakingAssetID(ctx context.Context, subnetID ids.ID) (ids.ID, error)
+	// GetSubnetType returns whether the subnet has been transformed to
+	// elastic, to choose how validators are added to it. Fails with
+	// "ErrUnknownSubnetType" if the node can't tell (e.g., it doesn't
+	// serve "getStakingAssetID").
+	GetSubnetType(ctx context.Context, subnetID ids.ID) (SubnetType, error)
+	// GetSubnetOwner reads back the create subnet tx, and returns the
+	// formatted P-Chain control addresses of the subnet and the number
+	// of their signatures required to manage it.
+	GetSubnetOwner(ctx context.Context, subnetID ids.ID) (threshold uint32, controlAddrs []string, err error)
+	// VerifySubnetOwners reads back the create subnet tx, and fails with
+	// "ErrSubnetOwnersMismatch" listing every difference if the subnet is
+	// not controlled by [expectedThreshold] of [expectedOwners].
+	VerifySubnetOwners(ctx context.Context, subnetID ids.ID, expectedThreshold uint32, expectedOwners []ids.ShortID) error
 	AddValidator(
 		ctx context.Context,
 		k key.Key,
@@ -64,6 +143,23 @@ type P interface {
 		end time.Time,
 		opts ...OpOption,
 	) (took time.Duration, err error)
+	// BuildAddSubnetValidator builds the tx of "AddSubnetValidator"
+	// without signing or issuing it, to inspect it before "SignPlan"
+	// and "IssuePlan".
+	BuildAddSubnetValidator(
+		ctx context.Context,
+		k key.Key,
+		subnetID ids.ID,
+		nodeID ids.ShortID,
+		start time.Time,
+		end time.Time,
+		weight uint64,
+		opts ...OpOption,
+	) (*TxPlan, error)
+	// SignPlan signs the tx of [plan] with [k], and verifies it.
+	SignPlan(ctx context.Context, k key.Key, plan *TxPlan) error
+	// IssuePlan issues the signed tx of [plan] and waits for it to commit.
+	IssuePlan(ctx context.Context, plan *TxPlan) (took time.Duration, err error)
 	AddSubnetValidator(
 		ctx context.Context,
 		k key.Key,
@@ -82,12 +178,135 @@ type P interface {
 		vmID ids.ID,
 		vmGenesis []byte,
 		opts ...OpOption,
-	) (blkChainID ids.ID, took time.Duration, err error)
+	) (blkChainID ids.ID, genesisHash string, took time.Duration, err error)
+	// VMAvailable returns true if the VM is installed on the connected
+	// node. A node without the VM plugin can't validate the blockchain,
+	// so check it before paying the fee for creating one.
+	VMAvailable(ctx context.Context, vmID ids.ID) (bool, error)
 	GetValidator(
 		ctx context.Context,
 		rsubnetID ids.ID,
 		nodeID ids.ShortID,
 	) (start time.Time, end time.Time, err error)
+	// GetValidatorDetails returns the validation period of [nodeID] with
+	// its uptime and connection, as observed by the connected node.
+	GetValidatorDetails(
+		ctx context.Context,
+		rsubnetID ids.ID,
+		nodeID ids.ShortID,
+	) (*ValidatorDetails, error)
+	// GetValidators looks up all [nodeIDs] in a single request, and
+	// returns the validation periods of the ones currently validating
+	// [rsubnetID]. The others are missing from the map.
+	GetValidators(
+		ctx context.Context,
+		rsubnetID ids.ID,
+		nodeIDs []ids.ShortID,
+	) (map[ids.ShortID]ValInfo, error)
+	// GetPendingValidators is "GetValidators" for the validators added to
+	// [rsubnetID] that have not started validating yet.
+	GetPendingValidators(
+		ctx context.Context,
+		rsubnetID ids.ID,
+		nodeIDs []ids.ShortID,
+	) (map[ids.ShortID]ValInfo, error)
+	// GetValidatorWithRetry retries "GetValidator" on "ErrValidatorNotFound"
+	// until the validator appears (e.g., right after adding it) or [timeout].
+	GetValidatorWithRetry(
+		ctx context.Context,
+		subnetID ids.ID,
+		nodeID ids.ShortID,
+		timeout time.Duration,
+	) (start time.Time, end time.Time, err error)
+	// GetValidatorUptime returns the primary network uptime of [nodeID]
+	// in [0, 1], as observed by the connected node.
+	GetValidatorUptime(ctx context.Context, nodeID ids.ShortID) (float64, error)
+	// GetPendingValidator returns the validation period of [nodeID] if it
+	// has been added to the subnet but has not started validating yet.
+	GetPendingValidator(
+		ctx context.Context,
+		rsubnetID ids.ID,
+		nodeID ids.ShortID,
+	) (start time.Time, end time.Time, err error)
+	// IsValidatorPending returns true with its start time if [nodeID] was
+	// added to the subnet but has not started validating yet.
+	IsValidatorPending(
+		ctx context.Context,
+		subnetID ids.ID,
+		nodeID ids.ShortID,
+	) (pending bool, start time.Time, err error)
+	// CancelValidator always fails with "ErrCannotCancelValidator", as
+	// issued staking txs are immutable. The error explains what to do
+	// depending on whether the validation has started.
+	CancelValidator(ctx context.Context, subnetID ids.ID, nodeID ids.ShortID) error
+	// Verify compares the on-chain state of the subnet, its blockchains,
+	// and validators against the manifest, and reports any drift.
+	Verify(ctx context.Context, m *Manifest) (*VerifyReport, error)
+	// GetBlockchainStatus returns the status of the blockchain on the
+	// connected node, without polling. Fails with "ErrBlockchainNotFound"
+	// if the node doesn't know the blockchain.
+	GetBlockchainStatus(ctx context.Context, blkChainID ids.ID) (pstatus.BlockchainStatus, error)
+	// WaitChainRPC polls the user-supplied [probe] until it succeeds,
+	// to wait until the created blockchain is actually queryable.
+	WaitChainRPC(
+		ctx context.Context,
+		blkChainID ids.ID,
+		probe func() error,
+	) (took time.Duration, err error)
+	// CreateBlockchains creates multiple blockchains under the same subnet,
+	// authorizing only once. Per-chain failures are reported in the results.
+	CreateBlockchains(
+		ctx context.Context,
+		key key.Key,
+		subnetID ids.ID,
+		specs []ChainSpec,
+		opts ...OpOption,
+	) (results []ChainResult, err error)
+	// SubscribeValidators polls the validator set of the subnet every
+	// [interval] and emits the changes. The first update reports the
+	// current set as added. The channel is closed once [ctx] is done.
+	SubscribeValidators(ctx context.Context, subnetID ids.ID, interval time.Duration) (<-chan ValidatorSetUpdate, error)
+	// PendingRewards sums the potential rewards of the key's current
+	// primary network validations and delegations (found by reward owner
+	// address), with the breakdown per position.
+	PendingRewards(ctx context.Context, k key.Key) (total uint64, positions []RewardPosition, err error)
+	// CrossChainBalances returns the P-Chain balance of the key, and the
+	// DJTX exported to it from the X-Chain that must be imported to the
+	// P-Chain before it can be spent there.
+	CrossChainBalances(ctx context.Context, k key.Key) (pChain uint64, xChainImportable uint64, err error)
+	// ImportableBalance returns the DJTX exported to the key from the
+	// X-Chain and not yet imported, without the P-Chain balance.
+	ImportableBalance(ctx context.Context, k key.Key) (uint64, error)
+	// UnlockSchedule returns the amounts of the key's stakeable-locked
+	// UTXOs grouped by the time they unlock, sorted by time.
+	UnlockSchedule(ctx context.Context, key key.Key) ([]UnlockEvent, error)
+	// ExportUTXOSnapshot fetches the UTXO set of the key and writes it
+	// to [path], recording the P-Chain height and time of the fetch.
+	// Load it with "LoadUTXOSnapshot" to build txs offline.
+	ExportUTXOSnapshot(ctx context.Context, key key.Key, path string) (*UTXOSnapshot, error)
+	// IssueRawTx decodes the hex-encoded signed tx (e.g., built by
+	// other tools), validates that it is a known P-Chain tx, and issues it.
+	// Pass "WithUTXOSnapshot" if the tx was built from a snapshot.
+	IssueRawTx(ctx context.Context, hexTx string, opts ...OpOption) (txID ids.ID, err error)
+	// GetSubnetValidators returns the current validators of the subnet
+	// mapped to their weights (stake amounts for the primary network).
+	GetSubnetValidators(
+		ctx context.Context,
+		rsubnetID ids.ID,
+	) (map[ids.ShortID]uint64, error)
+	// DiffValidators compares the [desired] validator set against
+	// the current one on-chain and returns the delta to converge.
+	DiffValidators(
+		ctx context.Context,
+		subnetID ids.ID,
+		desired map[ids.ShortID]uint64,
+	) (toAdd []ids.ShortID, toRemove []ids.ShortID, weightChanges map[ids.ShortID]uint64, err error)
+	// ExportSubnetConfig returns the YAML "SubnetConfig" of the subnet's
+	// owners, blockchains, and current validators, with no secrets.
+	ExportSubnetConfig(ctx context.Context, subnetID ids.ID) ([]byte, error)
+	// PlanSubnetConfig returns the validator changes to converge the subnet
+	// to [cfg] (e.g., parsed with "ParseSubnetConfig"), without issuing any.
+	PlanSubnetConfig(ctx context.Context, cfg *SubnetConfig) (*SubnetConfigPlan, error)
 }
 
 type p struct {
@@ -100,6 +319,9 @@ type p struct {
 	cli     platformvm.Client
 	info    api_info.Client
 	checker internal_platformvm.Checker
+
+	stakingMu     sync.Mutex
+	stakingParams *StakingParams
 }
 
 func (pc *p) Client() platformvm.Client            { return pc.cli }
@@ -113,31 +335,121 @@ func (pc *p) Balance(ctx context.Context, key key.Key) (uint64, error) {
 	return uint64(pb.Balance), nil
 }
 
+func (pc *p) SpendableBalance(ctx context.Context, k key.Key, at time.Time) (uint64, error) {
+	utxos, err := pc.getUTXOs(ctx, k, &Op{})
+	if err != nil {
+		return 0, err
+	}
+	now := uint64(at.Unix())
+	total, _ := k.Spends(pc.spendableUTXOs(utxos, now), key.WithTime(now))
+	return total, nil
+}
+
+// spendableUTXOs returns the DJTX UTXOs of [utxos] that aren't
+// stakeable-locked at [now], with the unlocked outputs unwrapped.
+// ref. "p.stake" for the same spendability rules
+func (pc *p) spendableUTXOs(utxos []*djtx.UTXO, now uint64) []*djtx.UTXO {
+	spendable := make([]*djtx.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		// assume "AssetID" is set to "DJTX" asset ID
+		if utxo.AssetID() != pc.assetID {
+			continue
+		}
+		inner, ok := utxo.Out.(*platformvm.StakeableLockOut)
+		if !ok {
+			spendable = append(spendable, utxo)
+			continue
+		}
+		if inner.Locktime > now {
+			// output still locked at [now]
+			continue
+		}
+		// copy to not modify the UTXO from "WithUTXOs"
+		unlocked := *utxo
+		unlocked.Out = inner.TransferableOut
+		spendable = append(spendable, &unlocked)
+	}
+	return spendable
+}
+
+// maximum number of UTXOs the node returns per "GetUTXOs" page
+const maxUTXOsPerPage = 1024
+
+func (pc *p) HasBalance(ctx context.Context, k key.Key, atLeast uint64) (bool, error) {
+	if atLeast == 0 {
+		return true, nil
+	}
+
+	now := uint64(time.Now().Unix())
+	total := uint64(0)
+	startAddr, startUTXOID := "", ""
+	for {
+		ubs, lastIndex, err := pc.cli.GetUTXOs(ctx, []string{k.P()}, maxUTXOsPerPage, startAddr, startUTXOID)
+		if err != nil {
+			return false, err
+		}
+		utxos, err := parseUTXOs(ubs)
+		if err != nil {
+			return false, err
+		}
+		// only what the key can spend now, as "SpendableBalance"
+		amount, _ := k.Spends(pc.spendableUTXOs(utxos, now), key.WithTime(now))
+		total, err = AddAmounts(total, amount)
+		if err != nil {
+			return false, err
+		}
+		if total >= atLeast {
+			return true, nil
+		}
+		if len(ubs) < maxUTXOsPerPage {
+			// no more pages
+			return false, nil
+		}
+		startAddr, startUTXOID = lastIndex.Address, lastIndex.UTXO
+	}
+}
+
 // ref. "platformvm.VM.newCreateSubnetTx".
 func (pc *p) CreateSubnet(
 	ctx context.Context,
 	k key.Key,
 	opts ...OpOption,
-) (subnetID ids.ID, took time.Duration, err error) {
+) (subnetID ids.ID, owner *SubnetOwner, took time.Duration, err error) {
 	ret := &Op{}
 	ret.applyOpts(opts)
 
 	fi, err := pc.info.GetTxFee(ctx)
 	if err != nil {
-		return ids.Empty, 0, err
+		return ids.Empty, nil, 0, err
+	}
+	createSubnetTxFee := Fee(fi, OperationCreateSubnet)
+
+	if ret.ownerLocktime != 0 && ret.ownerLocktime <= uint64(time.Now().Unix()) {
+		return ids.Empty, nil, 0, fmt.Errorf("%w: %d is not in the future", ErrInvalidOwnerLocktime, ret.ownerLocktime)
 	}
-	createSubnetTxFee := uint64(fi.CreateSubnetTxFee)
 
 	zap.L().Info("creating subnet",
 		zap.Bool("dryMode", ret.dryMode),
+		zap.Uint64("ownerLocktime", ret.ownerLocktime),
 		zap.String("assetId", pc.assetID.String()),
 		zap.Uint64("createSubnetTxFee", createSubnetTxFee),
 	)
-	ins, returnedOuts, _, err := pc.stake(ctx, k, createSubnetTxFee)
+	ins, returnedOuts, _, err := pc.stake(ctx, k, createSubnetTxFee, WithUTXOs(ret.utxos), WithUTXOHeight(ret.utxoHeight), withDust(ret), WithCoinSelection(ret.coinSelection), WithUTXORefetchRetry(ret.utxoRefetchRetry), WithMaxInputs(ret.maxInputs))
 	if err != nil {
-		return ids.Empty, 0, err
+		return ids.Empty, nil, 0, err
 	}
 
+	subnetOwners := &secp256k1fx.OutputOwners{
+		// owners can only manage this subnet after [locktime]
+		Locktime: ret.ownerLocktime,
+
+		// [threshold] of [ownerAddrs] needed to manage this subnet
+		Threshold: 1,
+
+		// address to send change to, if there is any,
+		// control addresses for the new subnet
+		Addrs: []ids.ShortID{k.Address()},
+	}
 	utx := &platformvm.UnsignedCreateSubnetTx{
 		BaseTx: platformvm.BaseTx{BaseTx: .BaseTx{
 			NetworkID:    pc.networkID,
@@ -145,84 +457,283 @@ func (pc *p) CreateSubnet(
 			Ins:          ins,
 			Outs:         returnedOuts,
 		}},
-		Owner: &secp256k1fx.OutputOwners{
-			// [threshold] of [ownerAddrs] needed to manage this subnet
-			Threshold: 1,
-
-			// address to send change to, if there is any,
-			// control addresses for the new subnet
-			Addrs: []ids.ShortID{k.Address()},
-		},
+		Owner: subnetOwners,
+	}
+	if err := checkTxSize(utx); err != nil {
+		return ids.Empty, nil, 0, err
 	}
 	pTx := &platformvm.Tx{
 		UnsignedTx: utx,
 	}
-	if err := k.Sign(pTx, len(ins)); err != nil {
-		return ids.Empty, 0, err
+	if err := k.Sign(ctx, pTx, len(ins)); err != nil {
+		return ids.Empty, nil, 0, err
 	}
-	if err := utx.SyntacticVerify(&snow.Context{
-		NetworkID: pc.networkID,
-		ChainID:   pc.pChainID,
-	}); err != nil {
-		return ids.Empty, 0, err
+	if err := pc.syntacticVerify(ret, utx); err != nil {
+		return ids.Empty, nil, 0, err
 	}
 
 	// subnet tx ID is the subnet ID based on ins/outs
 	subnetID = pTx.ID()
+	if ret.expectedSubnetID != ids.Empty && subnetID != ret.expectedSubnetID {
+		return subnetID, nil, 0, fmt.Errorf("%w: %s (expected %s)", ErrUnexpectedSubnetID, subnetID, ret.expectedSubnetID)
+	}
 	if ret.dryMode {
-		return subnetID, 0, nil
+		// not issued, so only the owner of the tx built
+		owner, err = pc.formatOwner(subnetOwners)
+		return subnetID, owner, 0, err
 	}
 
+	if err := pc.checkSnapshot(ctx, ret, k.P(), pTx); err != nil {
+		return subnetID, nil, 0, err
+	}
+	if err := pc.preflight(ctx, ret, k.P(), pTx); err != nil {
+		return subnetID, nil, 0, err
+	}
 	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
+	pc.audit(ret, k.P(), pTx, createSubnetTxFee, err)
 	if err != nil {
-		return subnetID, 0, fmt.Errorf("failed to issue tx: %w", err)
+		return subnetID, nil, 0, fmt.Errorf("failed to issue tx: %w", err)
 	}
 	if txID != subnetID {
-		return subnetID, 0, ErrUnexpectedSubnetID
+		return subnetID, nil, 0, ErrUnexpectedSubnetID
 	}
 
 	took, err = pc.checker.PollSubnet(ctx, txID)
-	return txID, took, err
+	if err != nil {
+		return txID, nil, took, err
+	}
+
+	// read back the tx, to confirm who controls the subnet
+	threshold, controlAddrs, err := pc.GetSubnetOwner(ctx, txID)
+	if err != nil {
+		return txID, nil, took, err
+	}
+	return txID, &SubnetOwner{Threshold: threshold, ControlAddrs: controlAddrs}, took, nil
 }
 
-func (pc *p) GetValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.ShortID) (start time.Time, end time.Time, err error) {
-	// If no [rsubnetID] is provided, just use the PrimaryNetworkID value.
-	subnetID := constants.PrimaryNetworkID
-	if rsubnetID != ids.Empty {
-		subnetID = rsubnetID
+func (pc *p) ReserveSubnet(
+	ctx context.Context,
+	k key.Key,
+	opts ...OpOption,
+) (subnetID ids.ID, utxos []*djtx.UTXO, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+
+	// pin the inputs, so that the signed tx (and its ID) is deterministic
+	utxos, err = pc.getUTXOs(ctx, k, ret)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	opts = append(opts, WithUTXOs(utxos), WithDryMode(true))
+	subnetID, _, _, err = pc.CreateSubnet(ctx, k, opts...)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	zap.L().Info("reserved subnet ID",
+		zap.String("subnetId", subnetID.String()),
+		zap.Int("utxos", len(utxos)),
+	)
+	return subnetID, utxos, nil
+}
+
+// subnetOrPrimary returns [id], or the primary network ID if no subnet
+// ID is provided.
+func subnetOrPrimary(id ids.ID) ids.ID {
+	if id == ids.Empty {
+		return constants.PrimaryNetworkID
 	}
+	return id
+}
+
+func (pc *p) GetValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.ShortID) (start time.Time, end time.Time, err error) {
+	subnetID := subnetOrPrimary(rsubnetID)
 
 	// Find validator data associated with [nodeID]
 	vs, err := pc.Client().GetCurrentValidators(ctx, subnetID, []ids.ShortID{nodeID})
 	if err != nil {
 		return time.Time{}, time.Time{}, err
 	}
+	return parseValidatorPeriod(vs, nodeID)
+}
 
-	// If the validator is not found, it will return a string record indicating
-	// that it was "unable to get mainnet validator record".
-	if len(vs) < 1 {
-		return time.Time{}, time.Time{}, ErrValidatorNotFound
+// ValidatorDetails is the validation period of a current validator, with
+// its uptime in [0, 1] and connection as observed by the connected node.
+type ValidatorDetails struct {
+	Start     time.Time
+	End       time.Time
+	Uptime    float64
+	Connected bool
+}
+
+func (pc *p) GetValidatorDetails(ctx context.Context, rsubnetID ids.ID, nodeID ids.ShortID) (*ValidatorDetails, error) {
+	subnetID := subnetOrPrimary(rsubnetID)
+
+	vs, err := pc.Client().GetCurrentValidators(ctx, subnetID, []ids.ShortID{nodeID})
+	if err != nil {
+		return nil, err
 	}
-	var validator map[string]interface{}
-	for _, v := range vs {
-		va, ok := v.(map[string]interface{})
-		if !ok {
-			return time.Time{}, time.Time{}, fmt.Errorf("%w: %T %+v", ErrInvalidValidatorData, v, v)
-		}
-		nodeIDs, ok := va["nodeID"].(string)
-		if !ok {
-			return time.Time{}, time.Time{}, ErrInvalidValidatorData
+	start, end, err := parseValidatorPeriod(vs, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := findValidator(vs, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	details := &ValidatorDetails{Start: start, End: end}
+	// of format `json.Float32`, may be missing for subnet validators
+	if d, ok := validator["uptime"].(string); ok {
+		details.Uptime, err = strconv.ParseFloat(d, 64)
+		if err != nil {
+			return nil, err
 		}
-		if nodeIDs == nodeID.PrefixedString(constants.NodeIDPrefix) {
-			validator = va
-			break
+	}
+	details.Connected, _ = validator["connected"].(bool)
+	return details, nil
+}
+
+// ValInfo is the validation period of a validator.
+type ValInfo struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (pc *p) GetValidators(ctx context.Context, rsubnetID ids.ID, nodeIDs []ids.ShortID) (map[ids.ShortID]ValInfo, error) {
+	subnetID := subnetOrPrimary(rsubnetID)
+
+	vs, err := pc.Client().GetCurrentValidators(ctx, subnetID, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	return parseValidatorPeriods(vs, nodeIDs)
+}
+
+func (pc *p) GetPendingValidators(ctx context.Context, rsubnetID ids.ID, nodeIDs []ids.ShortID) (map[ids.ShortID]ValInfo, error) {
+	subnetID := subnetOrPrimary(rsubnetID)
+
+	// (pending delegators are ignored)
+	vs, _, err := pc.Client().GetPendingValidators(ctx, subnetID, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	return parseValidatorPeriods(vs, nodeIDs)
+}
+
+// parseValidatorPeriods returns the validation periods of the [nodeIDs]
+// found in the validators [vs].
+func parseValidatorPeriods(vs []interface{}, nodeIDs []ids.ShortID) (map[ids.ShortID]ValInfo, error) {
+	vals := make(map[ids.ShortID]ValInfo, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		start, end, err := parseValidatorPeriod(vs, nodeID)
+		switch {
+		case errors.Is(err, ErrValidatorNotFound):
+			continue
+		case err != nil:
+			return nil, err
 		}
+		vals[nodeID] = ValInfo{Start: start, End: end}
+	}
+	return vals, nil
+}
+
+func (pc *p) GetValidatorWithRetry(
+	ctx context.Context,
+	subnetID ids.ID,
+	nodeID ids.ShortID,
+	timeout time.Duration,
+) (start time.Time, end time.Time, err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, perr := poll.New(pc.cfg.PollInterval).Poll(ctx, func() (done bool, err error) {
+		start, end, err = pc.GetValidator(ctx, subnetID, nodeID)
+		// only retry while the validator is not yet visible or the
+		// request failed transiently, and return any other error right away
+		return !errors.Is(err, ErrValidatorNotFound) && !retryable(err), nil
+	})
+	if perr != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %s after %v (%v)", ErrValidatorNotFound, nodeID, timeout, perr)
+	}
+	return start, end, err
+}
+
+func (pc *p) GetValidatorUptime(ctx context.Context, nodeID ids.ShortID) (float64, error) {
+	vs, err := pc.Client().GetCurrentValidators(ctx, constants.PrimaryNetworkID, []ids.ShortID{nodeID})
+	if err != nil {
+		return 0, err
+	}
+	validator, err := findValidator(vs, nodeID)
+	if err != nil {
+		return 0, err
+	}
+	// of format `json.Float32`
+	d, ok := validator["uptime"].(string)
+	if !ok {
+		return 0, ErrInvalidValidatorData
+	}
+	return strconv.ParseFloat(d, 64)
+}
+
+func (pc *p) GetPendingValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.ShortID) (start time.Time, end time.Time, err error) {
+	subnetID := subnetOrPrimary(rsubnetID)
+
+	// Find pending validator data associated with [nodeID]
+	// (pending delegators are ignored)
+	vs, _, err := pc.Client().GetPendingValidators(ctx, subnetID, []ids.ShortID{nodeID})
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return parseValidatorPeriod(vs, nodeID)
+}
+
+func (pc *p) IsValidatorPending(ctx context.Context, subnetID ids.ID, nodeID ids.ShortID) (bool, time.Time, error) {
+	start, _, err := pc.GetPendingValidator(ctx, subnetID, nodeID)
+	switch {
+	case errors.Is(err, ErrValidatorNotFound):
+		return false, time.Time{}, nil
+	case err != nil:
+		return false, time.Time{}, err
 	}
-	if validator == nil {
-		// This should never happen if the length of [vs] > 1, however,
-		// we defend against it in case.
-		return time.Time{}, time.Time{}, ErrValidatorNotFound
+	return true, start, nil
+}
+
+// The P-Chain has no tx to cancel a validation once its tx is committed,
+// even before it starts. The stake stays locked until the end time, so
+// the period can only be chosen more carefully for the next validation.
+func (pc *p) CancelValidator(ctx context.Context, subnetID ids.ID, nodeID ids.ShortID) error {
+	pending, start, err := pc.IsValidatorPending(ctx, subnetID, nodeID)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return fmt.Errorf("%w: %s is pending until %v, and staking txs are immutable; "+
+			"it will validate (and lock its stake) for the whole period, "+
+			"stopping the node only forfeits the rewards", ErrCannotCancelValidator, nodeID, start)
+	}
+	_, end, err := pc.GetValidator(ctx, subnetID, nodeID)
+	switch {
+	case errors.Is(err, ErrValidatorNotFound):
+		return fmt.Errorf("%w: %s is neither pending nor validating", ErrCannotCancelValidator, nodeID)
+	case err != nil:
+		return err
+	}
+	return fmt.Errorf("%w: %s is validating until %v, and staking txs are immutable; "+
+		"its stake is returned at the end of the period", ErrCannotCancelValidator, nodeID, end)
+}
+
+// parseValidatorPeriod finds the validator data associated with [nodeID]
+// in the "GetCurrentValidators"/"GetPendingValidators" response [vs], and
+// parses its start/end time.
+func parseValidatorPeriod(vs []interface{}, nodeID ids.ShortID) (start time.Time, end time.Time, err error) {
+	validator, err := findValidator(vs, nodeID)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
 	}
+	return validatorPeriod(validator)
+}
+
+// validatorPeriod parses the validation period of the [validator] record.
+func validatorPeriod(validator map[string]interface{}) (start time.Time, end time.Time, err error) {
 	// Parse start/end time once the validator data is found (of format
 	// `json.Uint64`)
 	d, ok := validator["startTime"].(string)
@@ -246,7 +757,89 @@ func (pc *p) GetValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.Shor
 	return start, end, nil
 }
 
-// ref. "platformvm.VM.newAddSubnetValidatorTx".
+// findValidator finds the validator data associated with [nodeID] in
+// the "GetCurrentValidators"/"GetPendingValidators" response [vs].
+func findValidator(vs []interface{}, nodeID ids.ShortID) (map[string]interface{}, error) {
+	// If the validator is not found, it will return a string record indicating
+	// that it was "unable to get mainnet validator record".
+	if len(vs) < 1 {
+		return nil, ErrValidatorNotFound
+	}
+	for _, v := range vs {
+		va, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %T %+v", ErrInvalidValidatorData, v, v)
+		}
+		nodeIDs, ok := va["nodeID"].(string)
+		if !ok {
+			return nil, ErrInvalidValidatorData
+		}
+		if nodeIDs == nodeID.PrefixedString(constants.NodeIDPrefix) {
+			return va, nil
+		}
+	}
+	// This should never happen if the length of [vs] > 1, however,
+	// we defend against it in case.
+	return nil, ErrValidatorNotFound
+}
+
+func (pc *p) GetSubnetValidators(ctx context.Context, rsubnetID ids.ID) (map[ids.ShortID]uint64, error) {
+	subnetID := subnetOrPrimary(rsubnetID)
+
+	vs, err := pc.Client().GetCurrentValidators(ctx, subnetID, nil)
+	if err != nil {
+		return nil, err
+	}
+	validators := make(map[ids.ShortID]uint64, len(vs))
+	for _, v := range vs {
+		va, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %T %+v", ErrInvalidValidatorData, v, v)
+		}
+		nodeIDs, ok := va["nodeID"].(string)
+		if !ok {
+			return nil, ErrInvalidValidatorData
+		}
+		nodeID, err := ids.ShortFromPrefixedString(nodeIDs, constants.NodeIDPrefix)
+		if err != nil {
+			return nil, err
+		}
+		weight, err := validatorWeight(va)
+		if err != nil {
+			return nil, err
+		}
+		validators[nodeID] = weight
+	}
+	return validators, nil
+}
+
+// validatorWeight parses the weight of the [validator] record.
+func validatorWeight(validator map[string]interface{}) (uint64, error) {
+	// subnet validators report "weight", primary network
+	// validators report "stakeAmount" (of format `json.Uint64`)
+	d, ok := validator["weight"].(string)
+	if !ok {
+		d, ok = validator["stakeAmount"].(string)
+	}
+	if !ok {
+		return 0, ErrInvalidValidatorData
+	}
+	return strconv.ParseUint(d, 10, 64)
+}
+
+func (pc *p) DiffValidators(
+	ctx context.Context,
+	subnetID ids.ID,
+	desired map[ids.ShortID]uint64,
+) (toAdd []ids.ShortID, toRemove []ids.ShortID, weightChanges map[ids.ShortID]uint64, err error) {
+	current, err := pc.GetSubnetValidators(ctx, subnetID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	toAdd, toRemove, weightChanges = diffValidators(current, desired)
+	return toAdd, toRemove, weightChanges, nil
+}
+
 func (pc *p) AddSubnetValidator(
 	ctx context.Context,
 	k key.Key,
@@ -257,60 +850,107 @@ func (pc *p) AddSubnetValidator(
 	weight uint64,
 	opts ...OpOption,
 ) (took time.Duration, err error) {
+	plan, err := pc.BuildAddSubnetValidator(ctx, k, subnetID, nodeID, start, end, weight, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if err := pc.SignPlan(ctx, k, plan); err != nil {
+		return 0, err
+	}
+	return pc.IssuePlan(ctx, plan)
+}
+
+// ref. "platformvm.VM.newAddSubnetValidatorTx".
+func (pc *p) BuildAddSubnetValidator(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	nodeID ids.ShortID,
+	start time.Time,
+	end time.Time,
+	weight uint64,
+	opts ...OpOption,
+) (*TxPlan, error) {
 	ret := &Op{}
 	ret.applyOpts(opts)
 
 	if subnetID == ids.Empty {
 		// same as "ErrNamedSubnetCantBePrimary"
 		// in case "subnetID == constants.PrimaryNetworkID"
-		return 0, ErrEmptyID
+		return nil, ErrEmptyID
 	}
 	if nodeID == ids.ShortEmpty {
-		return 0, ErrEmptyID
+		return nil, ErrEmptyID
 	}
 
-	_, _, err = pc.GetValidator(ctx, subnetID, nodeID)
+	_, _, err := pc.GetValidator(ctx, subnetID, nodeID)
 	if !errors.Is(err, ErrValidatorNotFound) {
-		return 0, ErrAlreadySubnetValidator
+		return nil, ErrAlreadySubnetValidator
 	}
 
-	validateStart, validateEnd, err := pc.GetValidator(ctx, ids.ID{}, nodeID)
-	if errors.Is(err, ErrValidatorNotFound) {
-		return 0, ErrNotValidatingPrimaryNetwork
-	} else if err != nil {
-		return 0, fmt.Errorf("%w: unable to get primary network validator record", err)
+	primary := ret.primaryValidator
+	if primary == nil {
+		primary, err = pc.GetValidatorDetails(ctx, ids.ID{}, nodeID)
+		if errors.Is(err, ErrValidatorNotFound) {
+			return nil, ErrNotValidatingPrimaryNetwork
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: unable to get primary network validator record", err)
+		}
 	}
-	// make sure the range is within staker validation start/end on the primary network
-	// TODO: official wallet client should define the error value for such case
+	if ret.requireConnected && !primary.Connected {
+		return nil, fmt.Errorf("%w: node %s is not connected to the primary network", ErrValidatorDisconnected, nodeID)
+	}
+	validateStart, validateEnd := primary.Start, primary.End
+	if ret.matchPrimaryWindow {
+		start, end = primaryWindow(validateStart, validateEnd, time.Now())
+		if !end.After(start) {
+			return nil, fmt.Errorf("%w (primary network validation ends at %v)", ErrInvalidSubnetValidatePeriod, validateEnd)
+		}
+		zap.L().Info("matching the primary network validation period",
+			zap.Time("start", start),
+			zap.Time("end", end),
+		)
+	}
+	pc.checkUptime(ctx, ret, nodeID, primary.Uptime)
+
+	// make sure the range is within staker validation start/end on the primary network
+	// TODO: official wallet client should define the error value for such case
 	// currently just returns "staking too short"
 	if start.Before(validateStart) {
-		return 0, fmt.Errorf("%w (validate start %v expected >%v)", ErrInvalidSubnetValidatePeriod, start, validateStart)
+		return nil, fmt.Errorf("%w (validate start %v expected >%v)", ErrInvalidSubnetValidatePeriod, start, validateStart)
 	}
 	if end.After(validateEnd) {
-		return 0, fmt.Errorf("%w (validate end %v expected <%v)", ErrInvalidSubnetValidatePeriod, end, validateEnd)
+		return nil, fmt.Errorf("%w (validate end %v expected <%v)", ErrInvalidSubnetValidatePeriod, end, validateEnd)
 	}
 
 	fi, err := pc.info.GetTxFee(ctx)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	txFee := uint64(fi.TxFee)
+	txFee := Fee(fi, OperationAddSubnetValidator)
 
 	zap.L().Info("adding subnet validator",
 		zap.String("subnetId", subnetID.String()),
 		zap.Uint64("txFee", txFee),
 		zap.Time("start", start),
 		zap.Time("end", end),
+		zap.String("period", StakingPeriodSummary(start, end)),
 		zap.Uint64("weight", weight),
 	)
-	ins, returnedOuts, _, err := pc.stake(ctx, k, txFee)
+	var inputSigners [][]ids.ShortID
+	ins, returnedOuts, _, err := pc.stake(ctx, k, txFee, WithUTXOs(ret.utxos), WithUTXOHeight(ret.utxoHeight), withDust(ret), WithCoinSelection(ret.coinSelection), WithUTXORefetchRetry(ret.utxoRefetchRetry), WithMaxInputs(ret.maxInputs), withInputSigners(&inputSigners))
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	subnetAuth, err := pc.authorize(ctx, k, subnetID)
+	subnetAuth, err := pc.authorize(ctx, k, subnetID, ret.authSigner)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	authSigner := ret.authSigner
+	if authSigner == ids.ShortEmpty {
+		authSigner = k.Address()
 	}
+	signers, _ := key.DedupSigners(append(inputSigners, []ids.ShortID{authSigner}))
 
 	utx := &platformvm.UnsignedAddSubnetValidatorTx{
 		BaseTx: platformvm.BaseTx{BaseTx: .BaseTx{
@@ -330,24 +970,53 @@ func (pc *p) AddSubnetValidator(
 		},
 		SubnetAuth: subnetAuth,
 	}
-	pTx := &platformvm.Tx{
-		UnsignedTx: utx,
-	}
-	if err := k.Sign(pTx, len(ins)+1); err != nil {
-		return 0, err
-	}
-	if err := utx.SyntacticVerify(&snow.Context{
-		NetworkID: pc.networkID,
-		ChainID:   pc.pChainID,
-	}); err != nil {
-		return 0, err
-	}
-	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
-	if err != nil {
-		return 0, fmt.Errorf("failed to issue tx: %w", err)
+	if err := checkTxSize(utx); err != nil {
+		return nil, err
 	}
+	return &TxPlan{
+		Ins:        ins,
+		Outs:       returnedOuts,
+		Signers:    signers,
+		Fee:        txFee,
+		UnsignedTx: utx,
+		NumSigs:    len(ins) + 1, // inputs + subnet auth
+		ret:        ret,
+	}, nil
+}
 
-	return pc.checker.PollTx(ctx, txID, pstatus.Committed)
+// safety margin within the primary network validation period,
+// also leaving the time to issue the tx before the start
+const primaryWindowMargin = 30 * time.Second
+
+// primaryWindow returns the subnet validation period within the primary
+// network validation period, starting no earlier than [now].
+func primaryWindow(validateStart, validateEnd, now time.Time) (start time.Time, end time.Time) {
+	start = validateStart
+	if start.Before(now) {
+		start = now
+	}
+	// the P-Chain records the period in seconds
+	start = start.Add(primaryWindowMargin).Truncate(time.Second)
+	end = validateEnd.Add(-primaryWindowMargin).Truncate(time.Second)
+	return start, end
+}
+
+// checkUptime warns if the primary network [uptime] of [nodeID] is below
+// the threshold, since a flaky primary network validator makes for a flaky
+// subnet validator. It never fails, as the uptime is only advisory.
+func (pc *p) checkUptime(ctx context.Context, ret *Op, nodeID ids.ShortID, uptime float64) {
+	minUptime := pc.getStakingParams(ctx).UptimeRequirement
+	if ret.minUptimeSet {
+		minUptime = ret.minUptime
+	}
+	if uptime < minUptime {
+		ret.warn(WarnLowUptime,
+			fmt.Sprintf("node %s primary network uptime %.2f%% is below %.2f%%", nodeID, uptime*100, minUptime*100),
+			zap.String("nodeId", nodeID.String()),
+			zap.Float64("uptime", uptime),
+			zap.Float64("minUptime", minUptime),
+		)
+	}
 }
 
 // ref. "platformvm.VM.newAddValidatorTx".
@@ -365,38 +1034,105 @@ func (pc *p) AddValidator(
 	if nodeID == ids.ShortEmpty {
 		return 0, ErrEmptyID
 	}
-
-	_, _, err = pc.GetValidator(ctx, ids.ID{}, nodeID)
-	if err == nil {
-		return 0, ErrAlreadyValidator
-	} else if !errors.Is(err, ErrValidatorNotFound) {
-		return 0, err
+	if ret.delegationFeeSet {
+		ret.rewardShares, err = pc.delegationFeeShares(ctx, ret, ret.delegationFee)
+		if err != nil {
+			return 0, err
+		}
+		ret.rewardSharesSet = true
 	}
 
-	// ref. https://docs.avax.network/learn/platform-overview/staking/#staking-parameters-on-avalanche
-	// ref. https://docs.avax.network/learn/platform-overview/staking/#validating-in-fuji
+	params := pc.getStakingParams(ctx)
 	if ret.stakeAmt == 0 {
-		switch pc.networkName {
-		case constants.MainnetName:
-			ret.stakeAmt = 2000 * units.Djtx
-		case constants.LocalName,
-			constants.TahoeName:
-			ret.stakeAmt = 1 * units.Djtx
-		}
-		zap.L().Info("stake amount not set, default to network setting",
+		ret.stakeAmt = params.MinValidatorStake
+		ret.warn(WarnStakeAmountDefaulted,
+			fmt.Sprintf("stake amount not set, default to %d nano-DJTX for %s", ret.stakeAmt, pc.networkName),
 			zap.String("networkName", pc.networkName),
 			zap.Uint64("stakeAmount", ret.stakeAmt),
 		)
 	}
+
+	// a previous attempt may have added the validator even if its
+	// response was lost, so retrying for the same period and stake
+	// is a no-op
+	added, err := pc.validatorAdded(ctx, nodeID, end, ret.stakeAmt)
+	if err != nil {
+		return 0, err
+	}
+	if added {
+		ret.warn(WarnValidatorAlreadyAdded,
+			fmt.Sprintf("validator %s already added until %v, skipping", nodeID, end),
+			zap.String("nodeId", nodeID.String()),
+			zap.Time("end", end),
+			zap.Uint64("stakeAmount", ret.stakeAmt),
+		)
+		return 0, nil
+	}
+	// 0% delegation fee is rarely intended, so only use it
+	// if explicitly set via "WithRewardShares(0)"
+	if !ret.rewardSharesSet {
+		ret.rewardShares = params.MinDelegationFee
+		ret.warn(WarnRewardSharesDefaulted,
+			fmt.Sprintf("reward shares not set, default to %d for %s", ret.rewardShares, pc.networkName),
+			zap.String("networkName", pc.networkName),
+			zap.Uint32("rewardShares", ret.rewardShares),
+		)
+	}
+	// on custom networks, only the minimum stake is the network's
+	if ret.stakeAmt < params.MinValidatorStake || ret.stakeAmt > params.MaxValidatorStake {
+		known := !params.Guessed || (params.MinStakeFetched && ret.stakeAmt < params.MinValidatorStake)
+		if err := pc.checkStakingBound(ret, known, fmt.Errorf("%w: %d (expected [%d, %d] on %s)",
+			ErrInvalidStakeAmount, ret.stakeAmt, params.MinValidatorStake, params.MaxValidatorStake, pc.networkName)); err != nil {
+			return 0, err
+		}
+	}
+	if d := end.Sub(start); d < params.MinStakeDuration || d > params.MaxStakeDuration {
+		if err := pc.checkStakingBound(ret, !params.Guessed, fmt.Errorf("%w: %v (expected [%v, %v] on %s)",
+			ErrInvalidValidatePeriod, d, params.MinStakeDuration, params.MaxStakeDuration, pc.networkName)); err != nil {
+			return 0, err
+		}
+	}
+	if ret.rewardPAddr != "" {
+		ret.rewardAddr, err = pc.parsePAddress(ret, ret.rewardPAddr)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if ret.rewardDerivedIndexSet {
+		deriver, ok := k.(interface {
+			DeriveAddress(idx uint32) (ids.ShortID, error)
+		})
+		if !ok {
+			return 0, fmt.Errorf("%w: %T is not a ledger key", ErrCantDeriveAddress, k)
+		}
+		ret.rewardAddr, err = deriver.DeriveAddress(ret.rewardDerivedIndex)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrCantDeriveAddress, err)
+		}
+		zap.L().Info("derived reward address",
+			zap.Uint32("accountIndex", ret.rewardDerivedIndex),
+			zap.String("rewardAddress", ret.rewardAddr.String()),
+		)
+	}
+	if ret.requireExplicitAddresses {
+		if ret.rewardAddr == ids.ShortEmpty {
+			return 0, fmt.Errorf("%w: reward address", ErrAddressRequired)
+		}
+		if ret.changeAddr == ids.ShortEmpty {
+			return 0, fmt.Errorf("%w: change address", ErrAddressRequired)
+		}
+	}
 	if ret.rewardAddr == ids.ShortEmpty {
 		ret.rewardAddr = k.Address()
-		zap.L().Warn("reward address not set, default to self",
+		ret.warn(WarnRewardAddressDefaulted,
+			fmt.Sprintf("reward address not set, default to self (%s)", k.P()),
 			zap.String("rewardAddress", ret.rewardAddr.String()),
 		)
 	}
 	if ret.changeAddr == ids.ShortEmpty {
 		ret.changeAddr = k.Address()
-		zap.L().Warn("change address not set",
+		ret.warn(WarnChangeAddressDefaulted,
+			fmt.Sprintf("change address not set, default to self (%s)", k.P()),
 			zap.String("changeAddress", ret.changeAddr.String()),
 		)
 	}
@@ -404,13 +1140,18 @@ func (pc *p) AddValidator(
 	zap.L().Info("adding validator",
 		zap.Time("start", start),
 		zap.Time("end", end),
+		zap.String("period", StakingPeriodSummary(start, end)),
 		zap.Uint64("stakeAmount", ret.stakeAmt),
 		zap.String("rewardAddress", ret.rewardAddr.String()),
 		zap.String("changeAddress", ret.changeAddr.String()),
 	)
 
 	// ref. https://docs.avax.network/learn/platform-overview/transaction-fees/#fee-schedule
-	addStakerTxFee := uint64(0)
+	fi, err := pc.info.GetTxFee(ctx)
+	if err != nil {
+		return 0, err
+	}
+	addStakerTxFee := Fee(fi, OperationAddValidator)
 
 	ins, returnedOuts, stakedOuts, err := pc.stake(
 		ctx,
@@ -420,6 +1161,12 @@ func (pc *p) AddValidator(
 		WithRewardAddress(ret.rewardAddr),
 		WithRewardShares(ret.rewardShares),
 		WithChangeAddress(ret.changeAddr),
+		WithUTXOs(ret.utxos),
+		WithUTXOHeight(ret.utxoHeight),
+		withDust(ret),
+		WithCoinSelection(ret.coinSelection),
+		WithUTXORefetchRetry(ret.utxoRefetchRetry),
+		WithMaxInputs(ret.maxInputs),
 	)
 	if err != nil {
 		return 0, err
@@ -446,27 +1193,88 @@ func (pc *p) AddValidator(
 		},
 		Shares: ret.rewardShares,
 	}
+	if err := checkTxSize(utx); err != nil {
+		return 0, err
+	}
 	pTx := &platformvm.Tx{
 		UnsignedTx: utx,
 	}
-	if err := k.Sign(pTx, len(ins)); err != nil {
+	if err := k.Sign(ctx, pTx, len(ins)); err != nil {
 		return 0, err
 	}
-	if err := utx.SyntacticVerify(&snow.Context{
-		NetworkID: pc.networkID,
-		ChainID:   pc.pChainID,
-	}); err != nil {
+	if err := pc.syntacticVerify(ret, utx); err != nil {
+		return 0, err
+	}
+	if err := pc.checkSnapshot(ctx, ret, k.P(), pTx); err != nil {
+		return 0, err
+	}
+	if err := pc.preflight(ctx, ret, k.P(), pTx); err != nil {
 		return 0, err
 	}
 	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
 	if err != nil {
-		return 0, fmt.Errorf("failed to issue tx: %w", err)
+		// the tx may have been issued even if the response was lost
+		status, serr := pc.cli.GetTxStatus(ctx, pTx.ID(), true)
+		if serr != nil || (status.Status != pstatus.Processing && status.Status != pstatus.Committed) {
+			pc.audit(ret, k.P(), pTx, addStakerTxFee, err)
+			return 0, fmt.Errorf("failed to issue tx: %w", err)
+		}
+		zap.L().Warn("failed to issue tx, but it was accepted by the node",
+			zap.String("txId", pTx.ID().String()),
+			zap.Error(err),
+		)
+		txID = pTx.ID()
 	}
+	pc.audit(ret, k.P(), pTx, addStakerTxFee, nil)
 
 	return pc.checker.PollTx(ctx, txID, pstatus.Committed)
 }
 
+// validatorAdded returns true if [nodeID] is a current or pending primary
+// network validator until [end] with [stakeAmt]. It fails with
+// "ErrAlreadyValidator" if the node validates for a different period or
+// stake.
+func (pc *p) validatorAdded(ctx context.Context, nodeID ids.ShortID, end time.Time, stakeAmt uint64) (bool, error) {
+	nodeIDs := []ids.ShortID{nodeID}
+	vs, err := pc.Client().GetCurrentValidators(ctx, constants.PrimaryNetworkID, nodeIDs)
+	if err != nil {
+		return false, err
+	}
+	validator, err := findValidator(vs, nodeID)
+	if errors.Is(err, ErrValidatorNotFound) {
+		vs, _, err = pc.Client().GetPendingValidators(ctx, constants.PrimaryNetworkID, nodeIDs)
+		if err != nil {
+			return false, err
+		}
+		validator, err = findValidator(vs, nodeID)
+	}
+	switch {
+	case errors.Is(err, ErrValidatorNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	_, vEnd, err := validatorPeriod(validator)
+	if err != nil {
+		return false, err
+	}
+	// validation period is recorded in seconds
+	if vEnd.Unix() != end.Unix() {
+		return false, fmt.Errorf("%w (validate end %v, expected %v)", ErrAlreadyValidator, vEnd, end)
+	}
+	weight, err := validatorWeight(validator)
+	if err != nil {
+		return false, err
+	}
+	if weight != stakeAmt {
+		return false, fmt.Errorf("%w (stake amount %d, expected %d)", ErrAlreadyValidator, weight, stakeAmt)
+	}
+	return true, nil
+}
+
 // ref. "platformvm.VM.newCreateChainTx".
+// It returns the "GenesisHash" of [vmGenesis] along with the blockchain ID,
+// to be recorded (e.g., in a deploy manifest) with the blockchain.
 func (pc *p) CreateBlockchain(
 	ctx context.Context,
 	k key.Key,
@@ -475,136 +1283,828 @@ func (pc *p) CreateBlockchain(
 	vmID ids.ID,
 	vmGenesis []byte,
 	opts ...OpOption,
-) (blkChainID ids.ID, took time.Duration, err error) {
+) (blkChainID ids.ID, genesisHash string, took time.Duration, err error) {
 	ret := &Op{}
 	ret.applyOpts(opts)
 
 	if subnetID == ids.Empty {
-		return ids.Empty, 0, ErrEmptyID
+		return ids.Empty, "", 0, ErrEmptyID
 	}
 	if vmID == ids.Empty {
-		return ids.Empty, 0, ErrEmptyID
+		return ids.Empty, "", 0, ErrEmptyID
+	}
+	chainName, err = ValidateChainName(chainName)
+	if err != nil {
+		return ids.Empty, "", 0, err
+	}
+	if err := pc.checkVM(ctx, ret, vmID); err != nil {
+		return ids.Empty, "", 0, err
+	}
+	genesisHash = GenesisHash(vmGenesis)
+
+	now := time.Now()
+	if ret.idempotencyCheck {
+		blkChainID, err = pc.findBlockchain(ctx, subnetID, chainName, vmID, vmGenesis)
+		if err != nil {
+			return ids.Empty, "", 0, err
+		}
+		if blkChainID != ids.Empty {
+			zap.L().Info("blockchain already created, skipping issue",
+				zap.String("subnetId", subnetID.String()),
+				zap.String("chainName", chainName),
+				zap.String("blockchainId", blkChainID.String()),
+			)
+			blkChainID, took, err = pc.pollBlockchain(ctx, ret, subnetID, blkChainID, now)
+			return blkChainID, genesisHash, took, err
+		}
+	}
+	if err := pc.checkChainName(ctx, ret, subnetID, chainName); err != nil {
+		return ids.Empty, "", 0, err
+	}
+
+	fi, err := pc.info.GetTxFee(ctx)
+	if err != nil {
+		return ids.Empty, "", 0, err
+	}
+	ret.phase(PhaseAuthorize, map[string]interface{}{"subnetId": subnetID.String()})
+	subnetAuth, err := pc.authorize(ctx, k, subnetID, ret.authSigner)
+	if err != nil {
+		return ids.Empty, "", 0, err
+	}
+	blkChainID, err = pc.issueCreateChainTx(
+		ctx,
+		k,
+		ret,
+		subnetID,
+		ChainSpec{Name: chainName, VMID: vmID, VMGenesis: vmGenesis},
+		Fee(fi, OperationCreateBlockchain),
+		subnetAuth,
+	)
+	if err != nil {
+		return ids.Empty, "", 0, err
+	}
+
+	blkChainID, took, err = pc.pollBlockchain(ctx, ret, subnetID, blkChainID, now)
+	return blkChainID, genesisHash, took, err
+}
+
+func (pc *p) VMAvailable(ctx context.Context, vmID ids.ID) (bool, error) {
+	vms, err := getVMs(ctx, pc.info)
+	if err != nil {
+		return false, err
+	}
+	_, ok := vms[vmID]
+	return ok, nil
+}
+
+// To fail with "ErrVMNotAvailable" if the VM is not installed on the
+// connected node, rather than only warning. Nodes that don't list their
+// VMs are still only warned about.
+func WithVerifyVMInstalled(b bool) OpOption {
+	return func(op *Op) {
+		op.verifyVMInstalled = b
+	}
+}
+
+// checkVM warns if the VM is not installed on the connected node.
+// It's only advisory unless "WithVerifyVMInstalled", since the other
+// validators may have the VM and older nodes may not list their VMs.
+func (pc *p) checkVM(ctx context.Context, ret *Op, vmID ids.ID) error {
+	ok, err := pc.VMAvailable(ctx, vmID)
+	switch {
+	case errors.Is(err, ErrGetVMsUnsupported):
+		zap.L().Warn("can't verify the VM is installed", zap.Error(err))
+	case err != nil && ret.verifyVMInstalled:
+		return err
+	case err != nil:
+		zap.L().Debug("failed to list VMs", zap.Error(err))
+	case !ok && ret.verifyVMInstalled:
+		return fmt.Errorf("%w: %s", ErrVMNotAvailable, vmID)
+	case !ok:
+		zap.L().Warn(fmt.Sprintf("%s %s", vmID, ErrVMNotAvailable))
+	}
+	return nil
+}
+
+// ChainSpec defines a blockchain to create with "CreateBlockchains".
+type ChainSpec struct {
+	Name      string
+	VMID      ids.ID
+	VMGenesis []byte
+}
+
+// ChainResult is the outcome of creating a single blockchain
+// with "CreateBlockchains".
+type ChainResult struct {
+	Spec         ChainSpec
+	BlockchainID ids.ID
+	// GenesisHash is the "GenesisHash" of the VM genesis.
+	GenesisHash string
+	Took        time.Duration
+	Err         error
+}
+
+// CreateBlockchains creates each of [specs] under the subnet, authorizing
+// against the subnet owner only once. Each blockchain is committed before
+// the next one is built, so their inputs don't conflict. A failure for one
+// blockchain is recorded in its result and doesn't stop the others.
+func (pc *p) CreateBlockchains(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	specs []ChainSpec,
+	opts ...OpOption,
+) (results []ChainResult, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+
+	if subnetID == ids.Empty {
+		return nil, ErrEmptyID
+	}
+
+	fi, err := pc.info.GetTxFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subnetAuth, err := pc.authorize(ctx, k, subnetID, ret.authSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	results = make([]ChainResult, len(specs))
+	for i, spec := range specs {
+		results[i] = ChainResult{Spec: spec, GenesisHash: GenesisHash(spec.VMGenesis)}
+		if spec.VMID == ids.Empty {
+			results[i].Err = ErrEmptyID
+			continue
+		}
+		spec.Name, err = ValidateChainName(spec.Name)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Spec = spec
+		if err := pc.checkVM(ctx, ret, spec.VMID); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		now := time.Now()
+		if ret.idempotencyCheck {
+			blkChainID, err := pc.findBlockchain(ctx, subnetID, spec.Name, spec.VMID, spec.VMGenesis)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			if blkChainID != ids.Empty {
+				zap.L().Info("blockchain already created, skipping issue",
+					zap.String("subnetId", subnetID.String()),
+					zap.String("chainName", spec.Name),
+					zap.String("blockchainId", blkChainID.String()),
+				)
+				results[i].BlockchainID, results[i].Took, results[i].Err = pc.pollBlockchain(ctx, ret, subnetID, blkChainID, now)
+				continue
+			}
+		}
+		if err := pc.checkChainName(ctx, ret, subnetID, spec.Name); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		blkChainID, err := pc.issueCreateChainTx(ctx, k, ret, subnetID, spec, Fee(fi, OperationCreateBlockchain), subnetAuth)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].BlockchainID = blkChainID
+		if !ret.poll {
+			// wait for the tx to be committed so that the next blockchain
+			// doesn't try to spend the same UTXOs
+			_, results[i].Err = pc.checker.PollTx(ctx, blkChainID, pstatus.Committed)
+			results[i].Took = time.Since(now)
+			continue
+		}
+		_, results[i].Took, results[i].Err = pc.pollBlockchain(ctx, ret, subnetID, blkChainID, now)
+	}
+	return results, nil
+}
+
+func (pc *p) issueCreateChainTx(
+	ctx context.Context,
+	k key.Key,
+	ret *Op,
+	subnetID ids.ID,
+	spec ChainSpec,
+	createBlkChainTxFee uint64,
+	subnetAuth verify.Verifiable,
+) (blkChainID ids.ID, err error) {
+	zap.L().Info("creating blockchain",
+		zap.String("subnetId", subnetID.String()),
+		zap.String("chainName", spec.Name),
+		zap.String("vmId", spec.VMID.String()),
+		zap.String("genesisHash", GenesisHash(spec.VMGenesis)),
+		zap.Uint64("createBlockchainTxFee", createBlkChainTxFee),
+	)
+	ret.phase(PhaseSelectUTXOs, map[string]interface{}{"fee": createBlkChainTxFee})
+	ins, returnedOuts, _, err := pc.stake(ctx, k, createBlkChainTxFee, WithUTXOs(ret.utxos), WithUTXOHeight(ret.utxoHeight), withDust(ret), WithCoinSelection(ret.coinSelection), WithUTXORefetchRetry(ret.utxoRefetchRetry), WithMaxInputs(ret.maxInputs))
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	utx := &platformvm.UnsignedCreateChainTx{
+		BaseTx: platformvm.BaseTx{BaseTx: djtx.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs:         returnedOuts,
+		}},
+		SubnetID:    subnetID,
+		ChainName:   spec.Name,
+		VMID:        spec.VMID,
+		FxIDs:       nil,
+		GenesisData: spec.VMGenesis,
+		SubnetAuth:  subnetAuth,
+	}
+	if err := checkTxSize(utx); err != nil {
+		return ids.Empty, err
+	}
+	pTx := &platformvm.Tx{
+		UnsignedTx: utx,
+	}
+	ret.phase(PhaseSign, map[string]interface{}{"inputs": len(ins)})
+	if err := k.Sign(ctx, pTx, len(ins)+1); err != nil {
+		return ids.Empty, err
+	}
+	if err := pc.syntacticVerify(ret, utx); err != nil {
+		return ids.Empty, err
+	}
+	if err := pc.checkSnapshot(ctx, ret, k.P(), pTx); err != nil {
+		return ids.Empty, err
+	}
+	if err := pc.preflight(ctx, ret, k.P(), pTx); err != nil {
+		return ids.Empty, err
+	}
+	ret.phase(PhaseIssue, map[string]interface{}{"txId": pTx.ID().String()})
+	blkChainID, err = pc.cli.IssueTx(ctx, pTx.Bytes())
+	pc.audit(ret, k.P(), pTx, createBlkChainTxFee, err)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to issue tx: %w", err)
+	}
+	return blkChainID, nil
+}
+
+func (pc *p) IssueRawTx(ctx context.Context, hexTx string, opts ...OpOption) (ids.ID, error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+
+	hexTx = strings.TrimPrefix(strings.TrimSpace(hexTx), "0x")
+	b, err := hex.DecodeString(hexTx)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to decode hex tx: %w", err)
+	}
+	tx, err := DecodeTx(b)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	if err := pc.checkSnapshot(ctx, ret, "", tx); err != nil {
+		return ids.Empty, err
+	}
+	zap.L().Info("issuing raw tx",
+		zap.String("txId", tx.ID().String()),
+		zap.String("txType", fmt.Sprintf("%T", tx.UnsignedTx)),
+	)
+	txID, err := pc.cli.IssueTx(ctx, b)
+	pc.audit(ret, "", tx, 0, err)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to issue tx: %w", err)
+	}
+	if txID != tx.ID() {
+		return txID, fmt.Errorf("%w: issued %s, expected %s", ErrUnexpectedTxID, txID, tx.ID())
+	}
+	return txID, nil
+}
+
+func (pc *p) GetBlockchainStatus(ctx context.Context, blkChainID ids.ID) (pstatus.BlockchainStatus, error) {
+	if blkChainID == ids.Empty {
+		return pstatus.UnknownChain, ErrEmptyID
+	}
+	status, err := pc.cli.GetBlockchainStatus(ctx, blkChainID.String())
+	if err != nil {
+		return pstatus.UnknownChain, err
+	}
+	if status == pstatus.UnknownChain {
+		return status, fmt.Errorf("%w: %s", ErrBlockchainNotFound, blkChainID)
+	}
+	return status, nil
+}
+
+func (pc *p) WaitChainRPC(ctx context.Context, blkChainID ids.ID, probe func() error) (took time.Duration, err error) {
+	return pc.checker.PollChainRPC(ctx, blkChainID, probe)
+}
+
+func (pc *p) pollBlockchain(
+	ctx context.Context,
+	ret *Op,
+	subnetID ids.ID,
+	blkChainID ids.ID,
+	start time.Time,
+) (ids.ID, time.Duration, error) {
+	took := time.Since(start)
+	if !ret.poll {
+		return blkChainID, took, nil
+	}
+	opts := []internal_platformvm.OpOption{
+		internal_platformvm.WithSubnetID(subnetID),
+		internal_platformvm.WithBlockchainID(blkChainID),
+		internal_platformvm.WithBlockchainStatus(pstatus.Validating),
+		internal_platformvm.WithCheckBlockchainBootstrapped(pc.info),
+	}
+	if ret.bootstrapProgress != nil {
+		opts = append(opts, internal_platformvm.WithBootstrapProgress(ret.bootstrapProgress))
+	}
+	if ret.bootstrapPercent != nil {
+		opts = append(opts, internal_platformvm.WithBootstrapPercent(ret.bootstrapPercent))
+	}
+	if ret.phaseCallback != nil {
+		opts = append(opts, internal_platformvm.WithBootstrapStart(func() {
+			ret.phase(PhasePollBootstrap, map[string]interface{}{"blockchainId": blkChainID.String()})
+		}))
+	}
+	ret.phase(PhasePollStatus, map[string]interface{}{"blockchainId": blkChainID.String()})
+	bTook, err := pc.checker.PollBlockchain(ctx, opts...)
+	return blkChainID, took + bTook, err
+}
+
+// findBlockchain returns the ID of the blockchain under [subnetID] that was
+// created with the same name, VM ID, and genesis, or ids.Empty if none exists.
+// A create-chain tx that timed out while polling may have been committed, so
+// this is used to avoid issuing a duplicate.
+func (pc *p) findBlockchain(
+	ctx context.Context,
+	subnetID ids.ID,
+	chainName string,
+	vmID ids.ID,
+	vmGenesis []byte,
+) (ids.ID, error) {
+	bcs, err := pc.cli.GetBlockchains(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+	for _, bc := range bcs {
+		if bc.SubnetID != subnetID || bc.Name != chainName || bc.VMID != vmID {
+			continue
+		}
+		tb, err := pc.getTx(ctx, bc.ID)
+		if err != nil {
+			return ids.Empty, err
+		}
+		tx, _, err := codec.DecodeTx(tb)
+		if err != nil {
+			return ids.Empty, err
+		}
+		chainTx, ok := tx.UnsignedTx.(*platformvm.UnsignedCreateChainTx)
+		if !ok {
+			return ids.Empty, ErrWrongTxType
+		}
+		if bytes.Equal(chainTx.GenesisData, vmGenesis) {
+			return bc.ID, nil
+		}
+	}
+	return ids.Empty, nil
+}
+
+// MaxTxSize is the maximum size of a tx accepted by the node.
+const MaxTxSize = internal_genesis.MaxTxSize
+
+// checkTxSize fails with "ErrTxTooLarge" if the marshaled unsigned tx
+// exceeds "MaxTxSize", so that it's caught before signing rather than
+// rejected by the node.
+func checkTxSize(utx platformvm.UnsignedTx) error {
+	b, err := codec.MarshalUnsignedTx(utx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+	}
+	if len(b) > MaxTxSize {
+		return fmt.Errorf("%w: %d bytes (limit %d bytes), consider consolidating UTXOs", ErrTxTooLarge, len(b), MaxTxSize)
+	}
+	return nil
+}
+
+// ParsePAddress parses the formatted P-Chain address (e.g., "P-custom1...").
+// Rewards and change are P-Chain outputs, and the P-Chain has no way to
+// send them to another chain, so it fails with "ErrInvalidRewardAddress"
+// for X-Chain and C-Chain addresses (a common copy-paste mistake).
+func ParsePAddress(addr string) (ids.ShortID, error) {
+	_, b, err := parsePAddress(addr)
+	if err != nil {
+		return ids.ShortEmpty, err
+	}
+	return ids.ToShortID(b)
+}
+
+func parsePAddress(addr string) (hrp string, b []byte, err error) {
+	addr = strings.TrimSpace(addr)
+	if strings.HasPrefix(addr, "0x") || strings.HasPrefix(addr, "0X") {
+		return "", nil, fmt.Errorf("%w: %q is a C-Chain (EVM) address, expected a P-Chain address (P-...)", ErrInvalidRewardAddress, addr)
+	}
+	chainAlias, hrp, b, err := formatting.ParseAddress(addr)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %q (%v)", ErrInvalidRewardAddress, addr, err)
+	}
+	if chainAlias != "P" {
+		// the same key has the same address bytes on every chain
+		pAddr, _ := formatting.FormatAddress("P", hrp, b)
+		return "", nil, fmt.Errorf("%w: %q is a %s-Chain address, expected a P-Chain address (e.g., %q for the same key)", ErrInvalidRewardAddress, addr, chainAlias, pAddr)
+	}
+	return hrp, b, nil
+}
+
+// parsePAddress parses the formatted P-Chain address with "ParsePAddress".
+// It only warns if the HRP doesn't match the network, as it is most likely
+// a copy-paste error but the address bytes are still valid.
+func (pc *p) parsePAddress(ret *Op, addr string) (ids.ShortID, error) {
+	hrp, b, err := parsePAddress(addr)
+	if err != nil {
+		return ids.ShortEmpty, err
+	}
+	if expected := constants.GetHRP(pc.networkID); hrp != expected {
+		ret.warn(WarnAddressHRPMismatch,
+			fmt.Sprintf("address %q HRP does not match %s, possibly a foreign-network address", addr, pc.networkName),
+			zap.String("address", addr),
+			zap.String("hrp", hrp),
+			zap.String("expectedHrp", expected),
+			zap.String("networkName", pc.networkName),
+		)
+	}
+	return ids.ToShortID(b)
+}
+
+// delegationFeeShares converts the delegation fee [percent] (e.g., 2.5 for
+// 2.5%) to "platformvm.PercentDenominator" units, and validates it against
+// the minimum delegation fee of the network (ref. "checkStakingBound").
+func (pc *p) delegationFeeShares(ctx context.Context, ret *Op, percent float64) (uint32, error) {
+	// also rejects NaN
+	if !(percent >= 0 && percent <= 100) {
+		return 0, fmt.Errorf("%w: %v%% (expected [0, 100])", ErrInvalidDelegationFee, percent)
+	}
+	shares := uint32(percent/100*platformvm.PercentDenominator + 0.5)
+
+	params := pc.getStakingParams(ctx)
+	if shares < params.MinDelegationFee {
+		if err := pc.checkStakingBound(ret, !params.Guessed, fmt.Errorf("%w: %d (expected >=%d on %s)",
+			ErrDelegationFeeTooLow, shares, params.MinDelegationFee, pc.networkName)); err != nil {
+			return 0, err
+		}
+	}
+	return shares, nil
+}
+
+// DecodeTx decodes the signed P-Chain tx bytes.
+// e.g., to inspect a tx built with "WithSkipVerify".
+func DecodeTx(b []byte) (*platformvm.Tx, error) {
+	tx, _, err := codec.DecodeTx(b)
+	return tx, err
+}
+
+func (pc *p) syntacticVerify(ret *Op, utx interface {
+	SyntacticVerify(*snow.Context) error
+}) error {
+	if ret.skipVerify {
+		zap.L().Warn("skipping syntactic verification")
+		return nil
+	}
+	return utx.SyntacticVerify(&snow.Context{
+		NetworkID: pc.networkID,
+		ChainID:   pc.pChainID,
+	})
+}
+
+type Op struct {
+	stakeAmt        uint64
+	rewardShares    uint32
+	rewardSharesSet bool
+
+	delegationFee    float64
+	delegationFeeSet bool
+
+	rewardAddr    ids.ShortID
+	rewardPAddr   string
+	changeAddr    ids.ShortID
+	ownerLocktime uint64
+
+	dryMode bool
+	poll    bool
+
+	matchPrimaryWindow bool
+	minUptime          float64
+	minUptimeSet       bool
+	requireConnected   bool
+	primaryValidator   *ValidatorDetails
+
+	warnings     *[]Warning
+	inputSigners *[][]ids.ShortID
+
+	idempotencyCheck  bool
+	uniqueChainName   bool
+	verifyVMInstalled bool
+	skipVerify        bool
+	preflight         bool
+	auditLogPath      string
+	phaseCallback     func(phase string, detail map[string]interface{})
+
+	utxos             []*djtx.UTXO
+	utxoHeight        uint64
+	snapshot          *UTXOSnapshot
+	snapshotTolerance time.Duration
+	dustThreshold     uint64
+	dustThresholdSet  bool
+	avoidDust         bool
+	authSigner        ids.ShortID
+	expectedSubnetID  ids.ID
+	bootstrapProgress func(internal_platformvm.BootstrapProgress)
+	bootstrapPercent  func(context.Context) (float64, error)
+	utxoRefetchRetry  bool
+	coinSelection     CoinSelection
+	maxInputs         int
+
+	requireExplicitAddresses bool
+
+	rewardDerivedIndex    uint32
+	rewardDerivedIndexSet bool
+}
+
+type OpOption func(*Op)
+
+func (op *Op) applyOpts(opts []OpOption) {
+	for _, opt := range opts {
+		opt(op)
+	}
+}
+
+func WithStakeAmount(v uint64) OpOption {
+	return func(op *Op) {
+		op.stakeAmt = v
+	}
+}
+
+// To set the delegation fee in "platformvm.PercentDenominator" units.
+// If unset, "AddValidator" defaults to the network setting (e.g., 2%),
+// so pass 0 to explicitly create a 0% fee validator.
+func WithRewardShares(v uint32) OpOption {
+	return func(op *Op) {
+		op.rewardShares = v
+		op.rewardSharesSet = true
+	}
+}
+
+// To set the delegation fee as a percentage (e.g., 2.5 for 2.5%).
+// It is converted to "platformvm.PercentDenominator" units and
+// overrides "WithRewardShares". Fails with "ErrDelegationFeeTooLow"
+// if below the network minimum, or only warns if the minimum is guessed
+// for a custom network.
+func WithDelegationFee(percent float64) OpOption {
+	return func(op *Op) {
+		op.delegationFee = percent
+		op.delegationFeeSet = true
+	}
+}
+
+func WithRewardAddress(v ids.ShortID) OpOption {
+	return func(op *Op) {
+		op.rewardAddr = v
+	}
+}
+
+// To set the reward address from its formatted P-Chain address
+// (e.g., "P-custom1..."), validated against the network.
+// Overrides "WithRewardAddress".
+func WithRewardPAddress(addr string) OpOption {
+	return func(op *Op) {
+		op.rewardPAddr = addr
+	}
+}
+
+func WithChangeAddress(v ids.ShortID) OpOption {
+	return func(op *Op) {
+		op.changeAddr = v
+	}
+}
+
+// To set the Unix time from which the subnet owners of "CreateSubnet"
+// can manage the subnet, for time-delayed governance. Must be in the
+// future. Defaults to 0, so the owners can manage it right away.
+func WithOwnerLocktime(v uint64) OpOption {
+	return func(op *Op) {
+		op.ownerLocktime = v
+	}
+}
+
+// To make "AddSubnetValidator" warn if the primary network uptime of the
+// node is below [uptime] in [0, 1], instead of the network's uptime
+// requirement for rewards.
+func WithMinUptime(uptime float64) OpOption {
+	return func(op *Op) {
+		op.minUptime = uptime
+		op.minUptimeSet = true
+	}
+}
+
+// To make "AddSubnetValidator" fail with "ErrValidatorDisconnected" if
+// the node's primary network validator is not reported connected, since
+// a disconnected node can't validate the subnet either.
+func WithRequireConnected(b bool) OpOption {
+	return func(op *Op) {
+		op.requireConnected = b
+	}
+}
+
+// To make "AddSubnetValidator" use the primary network validator record
+// [v] of the node (e.g., as already fetched to show its uptime), rather
+// than fetching it again. Ignored if nil.
+func WithPrimaryValidator(v *ValidatorDetails) OpOption {
+	return func(op *Op) {
+		op.primaryValidator = v
+	}
+}
+
+// To make "AddSubnetValidator" ignore the given start/end, and validate
+// for the node's primary network validation period (minus a safety margin).
+func WithMatchPrimaryWindow() OpOption {
+	return func(op *Op) {
+		op.matchPrimaryWindow = true
+	}
+}
+
+func WithDryMode(b bool) OpOption {
+	return func(op *Op) {
+		op.dryMode = b
 	}
+}
 
-	fi, err := pc.info.GetTxFee(ctx)
-	if err != nil {
-		return ids.Empty, 0, err
+// To fail "CreateSubnet" before issuing if the computed subnet ID is not
+// [subnetID] (e.g., as returned by "ReserveSubnet").
+func WithExpectedSubnetID(subnetID ids.ID) OpOption {
+	return func(op *Op) {
+		op.expectedSubnetID = subnetID
 	}
-	createBlkChainTxFee := uint64(fi.CreateBlockchainTxFee)
+}
 
-	now := time.Now()
-	zap.L().Info("creating blockchain",
-		zap.String("subnetId", subnetID.String()),
-		zap.String("chainName", chainName),
-		zap.String("vmId", vmID.String()),
-		zap.Uint64("createBlockchainTxFee", createBlkChainTxFee),
-	)
-	ins, returnedOuts, _, err := pc.stake(ctx, k, createBlkChainTxFee)
-	if err != nil {
-		return ids.Empty, 0, err
-	}
-	subnetAuth, err := pc.authorize(ctx, k, subnetID)
-	if err != nil {
-		return ids.Empty, 0, err
+// To report the progress of waiting for the created blockchain to
+// bootstrap (e.g., elapsed time).
+func WithBootstrapProgress(f func(internal_platformvm.BootstrapProgress)) OpOption {
+	return func(op *Op) {
+		op.bootstrapProgress = f
 	}
+}
 
-	utx := &platformvm.UnsignedCreateChainTx{
-		BaseTx: platformvm.BaseTx{BaseTx: .BaseTx{
-			NetworkID:    pc.networkID,
-			BlockchainID: pc.pChainID,
-			Ins:          ins,
-			Outs:         returnedOuts,
-		}},
-		SubnetID:    subnetID,
-		ChainName:   chainName,
-		VMID:        vmID,
-		FxIDs:       nil,
-		GenesisData: vmGenesis,
-		SubnetAuth:  subnetAuth,
+// To get the bootstrap completion percentage of the created blockchain
+// (e.g., from a VM-specific API), so "WithBootstrapProgress" reports an
+// ETA. Without it, the ETA is unknown.
+func WithBootstrapPercent(f func(context.Context) (float64, error)) OpOption {
+	return func(op *Op) {
+		op.bootstrapPercent = f
 	}
-	pTx := &platformvm.Tx{
-		UnsignedTx: utx,
+}
+
+// To fail "AddValidator" with "ErrAddressRequired" if the reward or change
+// address is not set, instead of defaulting it to the key address.
+func WithRequireExplicitAddresses(b bool) OpOption {
+	return func(op *Op) {
+		op.requireExplicitAddresses = b
 	}
-	if err := k.Sign(pTx, len(ins)+1); err != nil {
-		return ids.Empty, 0, err
+}
+
+// To send the rewards to the address of another account index [i] of the
+// same ledger device, keeping the signing account for operations only.
+// It overrides the reward address, and fails with "ErrCantDeriveAddress"
+// for keys that are not on a ledger.
+func WithRewardToDerivedIndex(i uint32) OpOption {
+	return func(op *Op) {
+		op.rewardDerivedIndex = i
+		op.rewardDerivedIndexSet = true
 	}
-	if err := utx.SyntacticVerify(&snow.Context{
-		NetworkID: pc.networkID,
-		ChainID:   pc.pChainID,
-	}); err != nil {
-		return ids.Empty, 0, err
+}
+
+// utxoRefetchAttempts bounds the refetches of "WithUTXORefetchRetry".
+const utxoRefetchAttempts = 5
+
+// To refetch the UTXOs a few times (every poll interval) if none are found,
+// as the UTXOs of a just-committed import may briefly not be visible,
+// or if the fetch failed transiently (e.g., a 5xx status).
+func WithUTXORefetchRetry(b bool) OpOption {
+	return func(op *Op) {
+		op.utxoRefetchRetry = b
 	}
-	blkChainID, err = pc.cli.IssueTx(ctx, pTx.Bytes())
-	if err != nil {
-		return ids.Empty, 0, fmt.Errorf("failed to issue tx: %w", err)
+}
+
+// To cap the number of UTXOs consumed by a tx, so that a key with many
+// small UTXOs fails early with "ErrTooManyInputs" rather than building a
+// tx larger than the node accepts. 0 means no cap.
+func WithMaxInputs(n int) OpOption {
+	return func(op *Op) {
+		op.maxInputs = n
 	}
+}
 
-	took = time.Since(now)
-	if ret.poll {
-		var bTook time.Duration
-		bTook, err = pc.checker.PollBlockchain(
-			ctx,
-			internal_platformvm.WithSubnetID(subnetID),
-			internal_platformvm.WithBlockchainID(blkChainID),
-			internal_platformvm.WithBlockchainStatus(pstatus.Validating),
-			internal_platformvm.WithCheckBlockchainBootstrapped(pc.info),
-		)
-		took += bTook
+func WithPoll(b bool) OpOption {
+	return func(op *Op) {
+		op.poll = b
 	}
-	return blkChainID, took, err
 }
 
-type Op struct {
-	stakeAmt     uint64
-	rewardShares uint32
-	rewardAddr   ids.ShortID
-	changeAddr   ids.ShortID
+// To skip the syntactic verification of the built tx before issuing it.
+//
+// DANGEROUS: only meant for debugging, e.g., to reproduce node-side
+// verification discrepancies with an intentionally malformed tx.
+// The node will likely reject the tx, and any fee may be lost.
+func WithSkipVerify(b bool) OpOption {
+	return func(op *Op) {
+		op.skipVerify = b
+	}
+}
 
-	dryMode bool
-	poll    bool
+// To check for a previously created blockchain with the same
+// subnet, name, VM ID, and genesis before issuing a new one.
+// If found, its ID is returned instead of creating a duplicate.
+func WithIdempotencyCheck(b bool) OpOption {
+	return func(op *Op) {
+		op.idempotencyCheck = b
+	}
 }
 
-type OpOption func(*Op)
+// To build the tx from the given UTXOs instead of fetching them
+// from the node, e.g., loaded from a snapshot for offline building.
+func WithUTXOs(utxos []*djtx.UTXO) OpOption {
+	return func(op *Op) {
+		op.utxos = utxos
+	}
+}
 
-func (op *Op) applyOpts(opts []OpOption) {
-	for _, opt := range opts {
-		opt(op)
+// To select the UTXOs as of the P-Chain [height], for deterministic tx
+// building (e.g., audit replay). The node only serves the latest UTXO
+// set, so it fails with "ErrHeightQueryUnsupported" unless [height] is
+// the latest accepted height. 0 to use the latest UTXOs.
+func WithUTXOHeight(height uint64) OpOption {
+	return func(op *Op) {
+		op.utxoHeight = height
 	}
 }
 
-func WithStakeAmount(v uint64) OpOption {
+// To check the UTXO snapshot the tx was built from before issuing it:
+// that it matches the network and the signer, and that its inputs are
+// still unspent. ref. "WithSnapshotTolerance".
+func WithUTXOSnapshot(s *UTXOSnapshot) OpOption {
 	return func(op *Op) {
-		op.stakeAmt = v
+		op.snapshot = s
 	}
 }
 
-func WithRewardShares(v uint32) OpOption {
+// To set how old the UTXO snapshot can be before the tx is rejected
+// with "ErrStaleSnapshot", if the node can't return the UTXOs to check
+// the inputs. Defaults to 10 minutes.
+func WithSnapshotTolerance(d time.Duration) OpOption {
 	return func(op *Op) {
-		op.rewardShares = v
+		op.snapshotTolerance = d
 	}
 }
 
-func WithRewardAddress(v ids.ShortID) OpOption {
+// defaultDustThreshold is the change amount below which "stake" considers
+// the change as dust, as such an output costs more to spend than it is worth.
+const defaultDustThreshold = units.MicroDjtx
+
+// To set the change amount (in nDJTX) below which the change is dust.
+// Defaults to 1 micro-DJTX, and 0 disables the dust handling.
+func WithDustThreshold(v uint64) OpOption {
 	return func(op *Op) {
-		op.rewardAddr = v
+		op.dustThreshold = v
+		op.dustThresholdSet = true
 	}
 }
 
-func WithChangeAddress(v ids.ShortID) OpOption {
+// To add the dust change to the burned fee rather than returning it
+// as a dust output. By default, the dust is returned with a warning.
+func WithAvoidDust(b bool) OpOption {
 	return func(op *Op) {
-		op.changeAddr = v
+		op.avoidDust = b
 	}
 }
 
-func WithDryMode(b bool) OpOption {
+// withInputSigners makes "stake" set [signers] to the addresses that
+// sign each of the returned inputs, in the same order.
+func withInputSigners(signers *[][]ids.ShortID) OpOption {
 	return func(op *Op) {
-		op.dryMode = b
+		op.inputSigners = signers
 	}
 }
 
-func WithPoll(b bool) OpOption {
+// withDust forwards the dust options of [src] to "stake", so that an
+// unset threshold still defaults.
+func withDust(src *Op) OpOption {
 	return func(op *Op) {
-		op.poll = b
+		op.dustThreshold = src.dustThreshold
+		op.dustThresholdSet = src.dustThresholdSet
+		op.avoidDust = src.avoidDust
 	}
 }
 
@@ -623,8 +2123,17 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 	if ret.changeAddr == ids.ShortEmpty {
 		ret.changeAddr = k.Address()
 	}
+	dustThreshold := ret.dustThreshold
+	if !ret.dustThresholdSet {
+		dustThreshold = defaultDustThreshold
+	}
+	// the stake and fee are consumed together, so their
+	// total must be representable
+	if _, err := AddAmounts(ret.stakeAmt, fee); err != nil {
+		return nil, nil, nil, err
+	}
 
-	ubs, _, err := pc.cli.GetAtomicUTXOs(ctx, []string{k.P()}, "", 100, "", "")
+	utxos, err := pc.getUTXOs(ctx, k, ret)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -635,13 +2144,11 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 	returnedOuts = make([]*.TransferableOutput, 0)
 	stakedOuts = make([]*.TransferableOutput, 0)
 
-	utxos := make([]*.UTXO, len(ubs))
-	for i, ub := range ubs {
-		utxos[i], err = internal_.ParseUTXO(ub, codec.PCodecManager)
-		if err != nil {
-			return nil, nil, nil, err
-		}
-	}
+	// set if "WithMaxInputs" stopped consuming UTXOs
+	capped := false
+
+	// owners that sign each consumed input, by input ID
+	signers := make(map[ids.ID][]ids.ShortID)
 
 	// amount of AVAX that has been staked
 	amountStaked := uint64(0)
@@ -651,6 +2158,10 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		if amountStaked >= ret.stakeAmt {
 			break
 		}
+		if ret.maxInputs > 0 && len(ins) >= ret.maxInputs {
+			capped = true
+			break
+		}
 		// assume "AssetID" is set to "DJTX" asset ID
 		if utxo.AssetID() != pc.assetID {
 			continue
@@ -723,17 +2234,22 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 
 		// add the input to the consumed inputs
 		ins = append(ins, in)
+		signers[in.InputID()] = inputSigners(&inner.OutputOwners, in)
 	}
 
 	// amount of AVAX that has been burned
 	amountBurned := uint64(0)
-	for _, utxo := range utxos {
+	for _, utxo := range burnOrder(utxos, ret.coinSelection) {
 		// have staked more AVAX then we need to
 		// have burned more AVAX then we need to
 		// no need to consume more AVAX
 		if amountStaked >= ret.stakeAmt && amountBurned >= fee {
 			break
 		}
+		if ret.maxInputs > 0 && len(ins) >= ret.maxInputs {
+			capped = true
+			break
+		}
 		// assume "AssetID" is set to "DJTX" asset ID
 		if utxo.AssetID() != pc.assetID {
 			continue
@@ -747,7 +2263,11 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 				// skip for next UTXO
 				continue
 			}
-			utxo.Out = inner.TransferableOut
+			// copy to not modify the UTXOs set via "WithUTXOs", so that
+			// building from the same UTXOs always yields the same tx
+			unlocked := *utxo
+			unlocked.Out = inner.TransferableOut
+			utxo = &unlocked
 		}
 		_, inputs := k.Spends([]*.UTXO{utxo}, key.WithTime(now))
 		if len(inputs) == 0 {
@@ -790,6 +2310,25 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 			})
 		}
 
+		switch {
+		case remainingValue == 0 || remainingValue >= dustThreshold:
+		case ret.avoidDust:
+			// not worth returning, so burn it along with the fee
+			zap.L().Info("burning dust change",
+				zap.Uint64("amount", remainingValue),
+				zap.Uint64("dustThreshold", dustThreshold),
+			)
+			amountBurned, err = AddAmounts(amountBurned, remainingValue)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			remainingValue = 0
+		default:
+			zap.L().Warn("returning dust change, which may cost more to spend than it is worth",
+				zap.Uint64("amount", remainingValue),
+				zap.Uint64("dustThreshold", dustThreshold),
+			)
+		}
 		if remainingValue > 0 {
 			// input had extra value, so some of it must be returned
 			returnedOuts = append(returnedOuts, &.TransferableOutput{
@@ -809,8 +2348,14 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 
 		// add the input to the consumed inputs
 		ins = append(ins, in)
+		if owned, ok := utxo.Out.(*secp256k1fx.TransferOutput); ok {
+			signers[in.InputID()] = inputSigners(&owned.OutputOwners, in)
+		}
 	}
 
+	if capped && (amountStaked < ret.stakeAmt || amountBurned < fee) {
+		return nil, nil, nil, fmt.Errorf("%w: %d inputs don't cover the stake and fee, consider consolidating UTXOs", ErrTooManyInputs, ret.maxInputs)
+	}
 	if amountStaked > 0 && amountStaked < ret.stakeAmt {
 		return nil, nil, nil, ErrInsufficientBalanceForStakeAmount
 	}
@@ -822,36 +2367,454 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 	.SortTransferableOutputs(returnedOuts, codec.PCodecManager) // sort outputs
 	.SortTransferableOutputs(stakedOuts, codec.PCodecManager)   // sort outputs
 
+	// the key signs once per distinct owner, not once per input
+	perInput := make([][]ids.ShortID, len(ins))
+	for i, in := range ins {
+		perInput[i] = signers[in.InputID()]
+	}
+	unique, _ := key.DedupSigners(perInput)
+	zap.L().Info("deduplicated input signers",
+		zap.Int("inputs", len(ins)),
+		zap.Int("signatures", len(unique)),
+	)
+	if ret.inputSigners != nil {
+		*ret.inputSigners = perInput
+	}
+
 	return ins, returnedOuts, stakedOuts, nil
 }
 
-// ref. "platformvm.VM.authorize".
-func (pc *p) authorize(ctx context.Context, k key.Key, subnetID ids.ID) (
-	auth verify.Verifiable, // input that names owners
-	err error,
-) {
-	tb, err := pc.cli.GetTx(ctx, subnetID)
+// inputSigners returns the addresses of [owners] that sign [in].
+func inputSigners(owners *secp256k1fx.OutputOwners, in *.TransferableInput) []ids.ShortID {
+	var sigIndices []uint32
+	switch tin := in.In.(type) {
+	case *secp256k1fx.TransferInput:
+		sigIndices = tin.SigIndices
+	case *platformvm.StakeableLockIn:
+		if inner, ok := tin.TransferableIn.(*secp256k1fx.TransferInput); ok {
+			sigIndices = inner.SigIndices
+		}
+	}
+	addrs := make([]ids.ShortID, 0, len(sigIndices))
+	for _, idx := range sigIndices {
+		if int(idx) < len(owners.Addrs) {
+			addrs = append(addrs, owners.Addrs[idx])
+		}
+	}
+	return addrs
+}
+
+// fetchUTXOs pages through all the UTXOs of the P-Chain address [pAddr],
+// so that keys with many UTXOs can spend all of their balance.
+func (pc *p) fetchUTXOs(ctx context.Context, pAddr string) ([][]byte, error) {
+	return pc.fetchAtomicUTXOs(ctx, pAddr, "")
+}
+
+// fetchAtomicUTXOs pages through the UTXOs of [pAddr] exported from the
+// [sourceChain] and not yet imported, or its P-Chain UTXOs if empty.
+func (pc *p) fetchAtomicUTXOs(ctx context.Context, pAddr string, sourceChain string) ([][]byte, error) {
+	all := make([][]byte, 0)
+	startAddr, startUTXOID := "", ""
+	for {
+		ubs, lastIndex, err := pc.cli.GetAtomicUTXOs(ctx, []string{pAddr}, sourceChain, maxUTXOsPerPage, startAddr, startUTXOID)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, ubs...)
+		if len(ubs) < maxUTXOsPerPage {
+			// no more pages
+			return all, nil
+		}
+		startAddr, startUTXOID = lastIndex.Address, lastIndex.UTXO
+	}
+}
+
+// getUTXOs returns the UTXOs set via "WithUTXOs",
+// or fetches the UTXOs of the key from the node.
+func (pc *p) getUTXOs(ctx context.Context, k key.Key, ret *Op) ([]*djtx.UTXO, error) {
+	if ret.utxos != nil {
+		return ret.utxos, nil
+	}
+	if ret.utxoHeight == 0 {
+		ubs, err := pc.fetchUTXOs(ctx, k.P())
+		for attempt := 1; (err == nil && len(ubs) == 0 || retryable(err)) && ret.utxoRefetchRetry && attempt <= utxoRefetchAttempts; attempt++ {
+			// the UTXOs of a just-committed import may not be visible yet
+			zap.L().Info("no UTXOs found, retrying",
+				zap.String("address", k.P()),
+				zap.Int("attempt", attempt),
+				zap.Error(err),
+			)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(pc.cfg.PollInterval):
+			}
+			ubs, err = pc.fetchUTXOs(ctx, k.P())
+		}
+		if err != nil {
+			return nil, err
+		}
+		return parseUTXOs(ubs)
+	}
+
+	// the node only serves the latest UTXO set, so the requested height
+	// can only be served while it is the latest accepted height
+	if err := pc.checkUTXOHeight(ctx, ret.utxoHeight); err != nil {
+		return nil, err
+	}
+	ubs, err := pc.fetchUTXOs(ctx, k.P())
 	if err != nil {
 		return nil, err
 	}
+	// the chain may have advanced during the fetch
+	if err := pc.checkUTXOHeight(ctx, ret.utxoHeight); err != nil {
+		return nil, err
+	}
+	return parseUTXOs(ubs)
+}
+
+func (pc *p) checkUTXOHeight(ctx context.Context, height uint64) error {
+	cur, err := pc.cli.GetHeight(ctx)
+	if err != nil {
+		return err
+	}
+	if cur != height {
+		return fmt.Errorf("%w: requested height %d, latest height %d", ErrHeightQueryUnsupported, height, cur)
+	}
+	return nil
+}
+
+func parseUTXOs(ubs [][]byte) ([]*djtx.UTXO, error) {
+	utxos := make([]*djtx.UTXO, len(ubs))
+	for i, ub := range ubs {
+		utxo, err := internal_djtx.ParseUTXO(ub, codec.PCodecManager)
+		if err != nil {
+			return nil, err
+		}
+		utxos[i] = utxo
+	}
+	return utxos, nil
+}
+
+// getTx fetches the signed bytes of [txID], in the encoding the codec
+// expects.
+func (pc *p) getTx(ctx context.Context, txID ids.ID) ([]byte, error) {
+	tb, err := pc.cli.GetTx(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	return unwrapTxBytes(tb)
+}
+
+// unwrapTxBytes decodes the tx bytes if the node returned them still
+// wrapped in their API encoding (e.g., a JSON string of "0x" prefixed
+// hex with checksum) rather than raw.
+func unwrapTxBytes(b []byte) ([]byte, error) {
+	s := strings.Trim(string(b), "\"")
+	if !strings.HasPrefix(s, "0x") {
+		return b, nil
+	}
+	raw, err := formatting.Decode(formatting.Hex, s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex tx bytes: %w", err)
+	}
+	return raw, nil
+}
 
-	tx := new(platformvm.Tx)
-	if _, err = codec.PCodecManager.Unmarshal(tb, tx); err != nil {
+func (pc *p) GetSubnetCreationTx(ctx context.Context, subnetID ids.ID) (*platformvm.UnsignedCreateSubnetTx, error) {
+	tb, err := pc.getTx(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, _, err := codec.DecodeTx(tb)
+	if err != nil {
 		return nil, err
 	}
 
 	subnetTx, ok := tx.UnsignedTx.(*platformvm.UnsignedCreateSubnetTx)
 	if !ok {
-		return nil, ErrWrongTxType
+		return nil, fmt.Errorf("%w: %s is %T, not a create subnet tx", ErrWrongTxType, subnetID, tx.UnsignedTx)
+	}
+	return subnetTx, nil
+}
+
+func (pc *p) SubnetValidatorCapacity(ctx context.Context, subnetID ids.ID) (current int, max int, err error) {
+	if subnetID == ids.Empty || subnetID == constants.PrimaryNetworkID {
+		return 0, 0, fmt.Errorf("%w: %s", ErrNotElasticSubnet, subnetID)
+	}
+	// fails if [subnetID] is not a subnet
+	if _, err := pc.GetSubnetCreationTx(ctx, subnetID); err != nil {
+		return 0, 0, err
+	}
+	// fails with "ErrNotElasticSubnet" without a "TransformSubnetTx"
+	if _, err := pc.GetStakingAssetID(ctx, subnetID); err != nil {
+		return 0, 0, err
+	}
+
+	// The node doesn't index the "TransformSubnetTx" by subnet, so read
+	// its parameters back from the node's subnet transformation. Every
+	// validator stakes at least the minimum, so the supply caps how many
+	// validators the subnet can have.
+	supply, err := pc.cli.GetCurrentSupply(ctx, subnetID)
+	if err != nil {
+		return 0, 0, err
+	}
+	minValidatorStake, _, err := pc.cli.GetMinStake(ctx, subnetID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if minValidatorStake == 0 {
+		// rejected by the "TransformSubnetTx" verification
+		return 0, 0, fmt.Errorf("%w: %s has no minimum validator stake", ErrNotElasticSubnet, subnetID)
+	}
+	capacity := supply / minValidatorStake
+	if maxInt := uint64(^uint(0) >> 1); capacity > maxInt {
+		capacity = maxInt
+	}
+
+	vs, err := pc.GetSubnetValidators(ctx, subnetID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(vs), int(capacity), nil
+}
+
+func (pc *p) GetStakingAssetID(ctx context.Context, subnetID ids.ID) (ids.ID, error) {
+	if subnetID == ids.Empty || subnetID == constants.PrimaryNetworkID {
+		return pc.assetID, nil
+	}
+	assetID, err := pc.cli.GetStakingAssetID(ctx, subnetID)
+	if err != nil {
+		// the node fails to find the subnet transformation for
+		// permissioned subnets, which is only reported as a message
+		if strings.Contains(err.Error(), "subnet transformation") {
+			return ids.Empty, fmt.Errorf("%w: %s (%v)", ErrNotElasticSubnet, subnetID, err)
+		}
+		return ids.Empty, err
+	}
+	return assetID, nil
+}
+
+// SubnetType is how validators join a subnet.
+type SubnetType uint8
+
+const (
+	// SubnetTypePermissioned subnets are validated by the nodes
+	// the subnet owners add with "AddSubnetValidator".
+	SubnetTypePermissioned SubnetType = iota
+	// SubnetTypeElastic subnets have been transformed to be validated
+	// by staking their own asset, permissionlessly.
+	SubnetTypeElastic
+)
+
+func (t SubnetType) String() string {
+	switch t {
+	case SubnetTypePermissioned:
+		return "permissioned"
+	case SubnetTypeElastic:
+		return "elastic"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+func (pc *p) GetSubnetType(ctx context.Context, subnetID ids.ID) (SubnetType, error) {
+	if subnetID == ids.Empty {
+		return 0, ErrEmptyID
+	}
+	// fails if [subnetID] is not a subnet
+	if _, err := pc.GetSubnetCreationTx(ctx, subnetID); err != nil {
+		return 0, err
+	}
+	_, err := pc.GetStakingAssetID(ctx, subnetID)
+	return classifySubnetType(err)
+}
+
+// classifySubnetType returns the subnet type from the "GetStakingAssetID"
+// error. Any failure that doesn't tell the type (e.g., a node without
+// "getStakingAssetID", or an unexpected message) is "ErrUnknownSubnetType",
+// except for a cancelled request.
+func classifySubnetType(err error) (SubnetType, error) {
+	switch {
+	case err == nil:
+		return SubnetTypeElastic, nil
+	case errors.Is(err, ErrNotElasticSubnet):
+		return SubnetTypePermissioned, nil
+	case errors.Is(err, context.Canceled):
+		return 0, err
+	default:
+		return 0, fmt.Errorf("%w: %v", ErrUnknownSubnetType, err)
+	}
+}
+
+// subnetOwner reads back the create subnet tx to find the subnet owners.
+func (pc *p) subnetOwner(ctx context.Context, subnetID ids.ID) (*secp256k1fx.OutputOwners, error) {
+	subnetTx, err := pc.GetSubnetCreationTx(ctx, subnetID)
+	if err != nil {
+		return nil, err
 	}
 
 	owner, ok := subnetTx.Owner.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return nil, ErrUnknownOwners
 	}
+	return owner, nil
+}
+
+func (pc *p) GetSubnetOwner(ctx context.Context, subnetID ids.ID) (threshold uint32, controlAddrs []string, err error) {
+	owner, err := pc.subnetOwner(ctx, subnetID)
+	if err != nil {
+		return 0, nil, err
+	}
+	so, err := pc.formatOwner(owner)
+	if err != nil {
+		return 0, nil, err
+	}
+	return so.Threshold, so.ControlAddrs, nil
+}
+
+// SubnetOwner is who controls a subnet: [Threshold] signatures of the
+// formatted P-Chain [ControlAddrs] are needed to manage it.
+type SubnetOwner struct {
+	Threshold    uint32   `json:"threshold"`
+	ControlAddrs []string `json:"controlAddresses"`
+}
+
+func (pc *p) formatOwner(owner *secp256k1fx.OutputOwners) (*SubnetOwner, error) {
+	hrp := constants.GetHRP(pc.networkID)
+	controlAddrs := make([]string, len(owner.Addrs))
+	for i, addr := range owner.Addrs {
+		var err error
+		controlAddrs[i], err = formatting.FormatAddress("P", hrp, addr[:])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &SubnetOwner{Threshold: owner.Threshold, ControlAddrs: controlAddrs}, nil
+}
+
+func (pc *p) VerifySubnetOwners(ctx context.Context, subnetID ids.ID, expectedThreshold uint32, expectedOwners []ids.ShortID) error {
+	owner, err := pc.subnetOwner(ctx, subnetID)
+	if err != nil {
+		return err
+	}
+
+	mismatches := make([]string, 0)
+	if owner.Threshold != expectedThreshold {
+		mismatches = append(mismatches, fmt.Sprintf("threshold %d, expected %d", owner.Threshold, expectedThreshold))
+	}
+	actual := make(map[ids.ShortID]struct{}, len(owner.Addrs))
+	for _, addr := range owner.Addrs {
+		actual[addr] = struct{}{}
+	}
+	expected := make(map[ids.ShortID]struct{}, len(expectedOwners))
+	for _, addr := range expectedOwners {
+		expected[addr] = struct{}{}
+		if _, ok := actual[addr]; !ok {
+			mismatches = append(mismatches, fmt.Sprintf("missing owner %s", addr))
+		}
+	}
+	for _, addr := range owner.Addrs {
+		if _, ok := expected[addr]; !ok {
+			mismatches = append(mismatches, fmt.Sprintf("unexpected owner %s", addr))
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w: subnet %s (%s)", ErrSubnetOwnersMismatch, subnetID, strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+// To pin the subnet owner address that authorizes the subnet operation,
+// when several owners could (e.g., a 1-of-N subnet). The address may be
+// any of the keys of a "key.MultiKey", which then signs the subnet auth.
+// It fails with "ErrAuthSignerNotOwner" if the address is not a subnet
+// owner, and with "ErrCantSign" if the key doesn't hold it.
+func WithAuthSigner(addr ids.ShortID) OpOption {
+	return func(op *Op) {
+		op.authSigner = addr
+	}
+}
+
+// authorize returns the subnet auth that [k] signs, as the [signer]
+// owner of the subnet (default to the key address).
+// ref. "platformvm.VM.authorize".
+func (pc *p) authorize(ctx context.Context, k key.Key, subnetID ids.ID, signer ids.ShortID) (
+	auth verify.Verifiable, // input that names owners
+	err error,
+) {
+	owner, err := pc.subnetOwner(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	return authorizeOwner(owner, k, signer)
+}
 
-	if len(owner.Addrs) != 1 || owner.Addrs[0] != k.Address() {
+// authorizeOwner returns the subnet auth of [owner] that [k] signs as
+// [signer], which may be any of the keys of a "key.MultiKey".
+func authorizeOwner(owner *secp256k1fx.OutputOwners, k key.Key, signer ids.ShortID) (verify.Verifiable, error) {
+	pinned := signer != ids.ShortEmpty
+	if !pinned {
+		signer = k.Address()
+	}
+	in, _, err := subnetAuth(owner, []ids.ShortID{signer})
+	switch {
+	case errors.Is(err, ErrAuthSignerNotOwner) && !pinned:
 		return nil, ErrCantSign
+	case err != nil:
+		return nil, err
+	}
+	// the key signs the subnet auth with a single signature
+	if owner.Threshold != 1 {
+		return nil, ErrCantSign
+	}
+	mk, ok := k.(key.MultiKey)
+	switch {
+	case ok:
+		// resets the auth key of any previous operation
+		if err := mk.SetAuthSigner(signer); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCantSign, err)
+		}
+	case signer != k.Address():
+		return nil, ErrCantSign
+	}
+	return in, nil
+}
+
+// subnetAuth returns the subnet auth input for [signers] of [owner],
+// in any order, and the signers in the order their signatures must be
+// in the credential. The signature indices must be sorted and unique
+// for the credential to verify, so they're not in the order of
+// [signers] unless it follows the (canonical) order of the owners.
+func subnetAuth(owner *secp256k1fx.OutputOwners, signers []ids.ShortID) (*secp256k1fx.Input, []ids.ShortID, error) {
+	indices := make(map[ids.ShortID]uint32, len(owner.Addrs))
+	for i, addr := range owner.Addrs {
+		indices[addr] = uint32(i)
+	}
+	sigIndices := make([]uint32, 0, len(signers))
+	seen := make(map[ids.ShortID]struct{}, len(signers))
+	for _, signer := range signers {
+		idx, ok := indices[signer]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrAuthSignerNotOwner, signer)
+		}
+		if _, ok := seen[signer]; ok {
+			continue
+		}
+		seen[signer] = struct{}{}
+		sigIndices = append(sigIndices, idx)
+	}
+	if uint32(len(sigIndices)) < owner.Threshold {
+		return nil, nil, fmt.Errorf("%w: %d signers for threshold %d", ErrCantSign, len(sigIndices), owner.Threshold)
+	}
+	// only the threshold signatures are expected
+	sort.Slice(sigIndices, func(i, j int) bool { return sigIndices[i] < sigIndices[j] })
+	sigIndices = sigIndices[:owner.Threshold]
+
+	ordered := make([]ids.ShortID, len(sigIndices))
+	for i, idx := range sigIndices {
+		ordered[i] = owner.Addrs[idx]
 	}
-	return &secp256k1fx.Input{SigIndices: []uint32{0}}, nil
+	return &secp256k1fx.Input{SigIndices: sigIndices}, ordered, nil
 }
@@ -0,0 +1,626 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/api"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/utils/formatting"
+	"github.com/lasthyphen/dijetsnodego/utils/rpc"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	pstatus "github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+
+	"github.com/lasthyphen/subnet-cli/client/clienttest"
+	"github.com/lasthyphen/subnet-cli/internal/key"
+)
+
+// newTestStaker returns the ewoq key and a local network client to stake
+// its UTXOs, set via "WithUTXOs".
+func newTestStaker(t *testing.T) (*key.SoftKey, *p) {
+	t.Helper()
+
+	k, err := key.NewSoft(12345, key.WithPrivateKeyEncoded(key.EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k, &p{networkID: 12345, assetID: ids.ID{1}, pChainID: ids.Empty}
+}
+
+// Independently built txs must be byte-identical for offline co-signing.
+func TestStakeDeterministic(t *testing.T) {
+	t.Parallel()
+
+	k, pc := newTestStaker(t)
+
+	utxos := []*djtx.UTXO{
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{2}),
+			clienttest.WithAmount(3*units.Djtx),
+			clienttest.WithOwners(1, k.Address()),
+		),
+		// stakeable lock that has already expired
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{3}),
+			clienttest.WithAmount(2*units.Djtx),
+			clienttest.WithLocktime(1),
+			clienttest.WithOwners(1, k.Address()),
+		),
+	}
+
+	build := func() []byte {
+		ins, returnedOuts, _, err := pc.stake(context.Background(), k, 4*units.Djtx, WithUTXOs(utxos))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pTx := &platformvm.Tx{
+			UnsignedTx: &platformvm.UnsignedCreateSubnetTx{
+				BaseTx: platformvm.BaseTx{BaseTx: djtx.BaseTx{
+					NetworkID:    pc.networkID,
+					BlockchainID: pc.pChainID,
+					Ins:          ins,
+					Outs:         returnedOuts,
+				}},
+				Owner: &secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{k.Address()},
+				},
+			},
+		}
+		if err := k.Sign(context.Background(), pTx, len(ins)); err != nil {
+			t.Fatal(err)
+		}
+		return pTx.Bytes()
+	}
+
+	b1, b2 := build(), build()
+	if !bytes.Equal(b1, b2) {
+		t.Fatalf("unexpected tx bytes %x, expected %x", b2, b1)
+	}
+}
+
+func TestStakeDust(t *testing.T) {
+	t.Parallel()
+
+	k, pc := newTestStaker(t)
+
+	utxos := []*djtx.UTXO{
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithAmount(units.Djtx+500),
+			clienttest.WithOwners(1, k.Address()),
+		),
+	}
+	tt := []struct {
+		opts        []OpOption
+		expectedOut uint64
+	}{
+		{opts: nil, expectedOut: 500},
+		{opts: []OpOption{WithAvoidDust(true)}, expectedOut: 0},
+		{opts: []OpOption{WithAvoidDust(true), WithDustThreshold(1)}, expectedOut: 500},
+		{opts: []OpOption{WithAvoidDust(true), WithDustThreshold(500)}, expectedOut: 500},
+		{opts: []OpOption{WithAvoidDust(true), WithDustThreshold(501)}, expectedOut: 0},
+		{opts: []OpOption{WithDustThreshold(501)}, expectedOut: 500},
+		{opts: []OpOption{WithAvoidDust(true), WithDustThreshold(0)}, expectedOut: 500},
+		{opts: []OpOption{withDust(&Op{avoidDust: true})}, expectedOut: 0},
+	}
+	for i, tv := range tt {
+		_, returnedOuts, _, err := pc.stake(context.Background(), k, units.Djtx, append(tv.opts, WithUTXOs(utxos))...)
+		if err != nil {
+			t.Fatalf("#%d: unexpected error %v", i, err)
+		}
+		out := uint64(0)
+		for _, o := range returnedOuts {
+			out += o.Out.Amount()
+		}
+		if out != tv.expectedOut {
+			t.Fatalf("#%d: unexpected change %d, expected %d", i, out, tv.expectedOut)
+		}
+	}
+}
+
+func TestStakeCoinSelection(t *testing.T) {
+	t.Parallel()
+
+	k, pc := newTestStaker(t)
+
+	utxos := []*djtx.UTXO{
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{2}),
+			clienttest.WithAmount(10*units.Djtx),
+			clienttest.WithOwners(1, k.Address()),
+		),
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{3}),
+			clienttest.WithAmount(units.Djtx),
+			clienttest.WithOwners(1, k.Address()),
+		),
+	}
+	tt := []struct {
+		s              CoinSelection
+		fee            uint64
+		maxInputs      int
+		expectedTxID   ids.ID
+		expectedChange uint64
+		expectedErr    error
+	}{
+		{s: CoinSelectionDefault, fee: units.Djtx, expectedTxID: ids.ID{2}, expectedChange: 9 * units.Djtx},
+		{s: CoinSelectionPreserveLiquid, fee: units.Djtx, expectedTxID: ids.ID{3}, expectedChange: 0},
+		{s: CoinSelectionDefault, fee: units.Djtx, maxInputs: 1, expectedTxID: ids.ID{2}, expectedChange: 9 * units.Djtx},
+		// needs both UTXOs
+		{s: CoinSelectionDefault, fee: 11 * units.Djtx, maxInputs: 1, expectedErr: ErrTooManyInputs},
+	}
+	for i, tv := range tt {
+		ins, returnedOuts, _, err := pc.stake(context.Background(), k, tv.fee, WithUTXOs(utxos), WithCoinSelection(tv.s), WithMaxInputs(tv.maxInputs))
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d: unexpected error %v, expected %v", i, err, tv.expectedErr)
+		}
+		if err != nil {
+			continue
+		}
+		if len(ins) != 1 || ins[0].TxID != tv.expectedTxID {
+			t.Fatalf("#%d: unexpected inputs %+v, expected to spend %s", i, ins, tv.expectedTxID)
+		}
+		change := uint64(0)
+		for _, o := range returnedOuts {
+			change += o.Out.Amount()
+		}
+		if change != tv.expectedChange {
+			t.Fatalf("#%d: unexpected change %d, expected %d", i, change, tv.expectedChange)
+		}
+	}
+	if utxos[0].TxID != (ids.ID{2}) {
+		t.Fatal("unexpected reorder of the given UTXOs")
+	}
+}
+
+func TestSubnetAuth(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := ids.ShortID{1}, ids.ShortID{2}, ids.ShortID{3}
+	owner := &secp256k1fx.OutputOwners{Threshold: 2, Addrs: []ids.ShortID{a, b, c}}
+
+	tt := []struct {
+		name            string
+		signers         []ids.ShortID
+		expectedIndices []uint32
+		expectedSigners []ids.ShortID
+		expectedErr     error
+	}{
+		{name: "canonical order", signers: []ids.ShortID{a, c}, expectedIndices: []uint32{0, 2}, expectedSigners: []ids.ShortID{a, c}},
+		{name: "reversed order", signers: []ids.ShortID{c, a}, expectedIndices: []uint32{0, 2}, expectedSigners: []ids.ShortID{a, c}},
+		{name: "more than threshold", signers: []ids.ShortID{c, b, a}, expectedIndices: []uint32{0, 1}, expectedSigners: []ids.ShortID{a, b}},
+		{name: "duplicate signer", signers: []ids.ShortID{c, c}, expectedErr: ErrCantSign},
+		{name: "not an owner", signers: []ids.ShortID{a, {4}}, expectedErr: ErrAuthSignerNotOwner},
+	}
+	for i, tv := range tt {
+		in, signers, err := subnetAuth(owner, tv.signers)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+		if err != nil {
+			continue
+		}
+		if err := in.Verify(); err != nil {
+			t.Fatalf("#%d(%s): unexpected invalid input %v", i, tv.name, err)
+		}
+		if !reflect.DeepEqual(in.SigIndices, tv.expectedIndices) {
+			t.Fatalf("#%d(%s): unexpected indices %v, expected %v", i, tv.name, in.SigIndices, tv.expectedIndices)
+		}
+		// each signature must come from the owner at its index
+		for j, idx := range in.SigIndices {
+			if signers[j] != owner.Addrs[idx] || signers[j] != tv.expectedSigners[j] {
+				t.Fatalf("#%d(%s): unexpected signer %s at %d", i, tv.name, signers[j], j)
+			}
+		}
+	}
+}
+
+// The pinned signer may be any key of a multi-key wallet.
+func TestAuthorizeOwnerMultiKey(t *testing.T) {
+	t.Parallel()
+
+	k1, err := key.NewSoft(12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := key.NewSoft(12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := key.NewSoftMulti(12345, [][]byte{k1.Raw(), k2.Raw()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{k2.Address()}}
+
+	tt := []struct {
+		name        string
+		k           key.Key
+		signer      ids.ShortID
+		expectedErr error
+	}{
+		{name: "primary not an owner", k: m, expectedErr: ErrCantSign},
+		{name: "pinned second key", k: m, signer: k2.Address()},
+		{name: "pinned key not held", k: k1, signer: k2.Address(), expectedErr: ErrCantSign},
+		{name: "pinned not an owner", k: m, signer: k1.Address(), expectedErr: ErrAuthSignerNotOwner},
+	}
+	for i, tv := range tt {
+		auth, err := authorizeOwner(owner, tv.k, tv.signer)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+		if err != nil {
+			continue
+		}
+
+		// the subnet auth credential (past the inputs) is signed by the pin
+		pTx := &platformvm.Tx{
+			UnsignedTx: &platformvm.UnsignedAddSubnetValidatorTx{SubnetAuth: auth},
+		}
+		if err := tv.k.Sign(context.Background(), pTx, 1); err != nil {
+			t.Fatal(err)
+		}
+		hash, _, err := key.SigningHash(pTx.UnsignedTx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cred, ok := pTx.Creds[0].(*secp256k1fx.Credential)
+		if !ok || len(cred.Sigs) != 1 {
+			t.Fatalf("#%d(%s): unexpected credential %+v", i, tv.name, pTx.Creds[0])
+		}
+		pk, err := (&crypto.FactorySECP256K1R{}).RecoverHashPublicKey(hash, cred.Sigs[0][:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pk.Address() != tv.signer {
+			t.Fatalf("#%d(%s): unexpected signer %s, expected %s", i, tv.name, pk.Address(), tv.signer)
+		}
+	}
+}
+
+func TestClassifySubnetType(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name         string
+		err          error
+		expectedType SubnetType
+		expectedErr  error
+	}{
+		{name: "staking asset", expectedType: SubnetTypeElastic},
+		{name: "no transformation", err: fmt.Errorf("%w: subnet", ErrNotElasticSubnet), expectedType: SubnetTypePermissioned},
+		{name: "method not found", err: errors.New("the method platform.getStakingAssetID does not exist/is not available"), expectedErr: ErrUnknownSubnetType},
+		{name: "unexpected message", err: errors.New("not found"), expectedErr: ErrUnknownSubnetType},
+		{name: "timeout", err: context.DeadlineExceeded, expectedErr: ErrUnknownSubnetType},
+		{name: "cancelled", err: context.Canceled, expectedErr: context.Canceled},
+	}
+	for i, tv := range tt {
+		subnetType, err := classifySubnetType(tv.err)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+		if err == nil && subnetType != tv.expectedType {
+			t.Fatalf("#%d(%s): unexpected type %s, expected %s", i, tv.name, subnetType, tv.expectedType)
+		}
+	}
+}
+
+func TestUnwrapTxBytes(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte{0, 0, 0, 0, 0, 16, 1, 2, 3}
+	hexTx, err := formatting.EncodeWithChecksum(formatting.Hex, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt := []struct {
+		name string
+		b    []byte
+	}{
+		{name: "raw", b: raw},
+		{name: "hex", b: []byte(hexTx)},
+		{name: "JSON hex", b: []byte(`"` + hexTx + `"`)},
+	}
+	for i, tv := range tt {
+		b, err := unwrapTxBytes(tv.b)
+		if err != nil {
+			t.Fatalf("#%d(%s): unexpected error %v", i, tv.name, err)
+		}
+		if !bytes.Equal(b, raw) {
+			t.Fatalf("#%d(%s): unexpected bytes %x, expected %x", i, tv.name, b, raw)
+		}
+	}
+	if _, err := unwrapTxBytes([]byte("0xzz")); err == nil {
+		t.Fatal("expected error for invalid hex")
+	}
+}
+
+func TestParsePAddress(t *testing.T) {
+	t.Parallel()
+
+	addr := ids.ShortID{1, 2, 3}
+	pAddr, err := formatting.FormatAddress("P", "custom", addr[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	xAddr, err := formatting.FormatAddress("X", "custom", addr[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt := []struct {
+		name   string
+		addr   string
+		expErr error
+	}{
+		{name: "P-Chain", addr: pAddr},
+		{name: "P-Chain with spaces", addr: " " + pAddr + "\n"},
+		{name: "X-Chain", addr: xAddr, expErr: ErrInvalidRewardAddress},
+		{name: "C-Chain", addr: "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC", expErr: ErrInvalidRewardAddress},
+		{name: "invalid", addr: "P-invalid", expErr: ErrInvalidRewardAddress},
+	}
+	for i, tv := range tt {
+		parsed, err := ParsePAddress(tv.addr)
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expErr)
+		}
+		if tv.expErr == nil && parsed != addr {
+			t.Fatalf("#%d(%s): unexpected address %s, expected %s", i, tv.name, parsed, addr)
+		}
+	}
+}
+
+// blockchainStatusClient reports [status] for any blockchain, or fails
+// with [err].
+type blockchainStatusClient struct {
+	platformvm.Client
+	status pstatus.BlockchainStatus
+	err    error
+}
+
+func (c *blockchainStatusClient) GetBlockchainStatus(context.Context, string, ...rpc.Option) (pstatus.BlockchainStatus, error) {
+	return c.status, c.err
+}
+
+func TestGetBlockchainStatus(t *testing.T) {
+	t.Parallel()
+
+	errUnreachable := errors.New("unreachable")
+	tt := []struct {
+		name        string
+		blkChainID  ids.ID
+		cli         *blockchainStatusClient
+		expected    pstatus.BlockchainStatus
+		expectedErr error
+	}{
+		{name: "validating", blkChainID: ids.ID{1}, cli: &blockchainStatusClient{status: pstatus.Validating}, expected: pstatus.Validating},
+		{name: "created", blkChainID: ids.ID{1}, cli: &blockchainStatusClient{status: pstatus.Created}, expected: pstatus.Created},
+		{name: "unknown", blkChainID: ids.ID{1}, cli: &blockchainStatusClient{status: pstatus.UnknownChain}, expected: pstatus.UnknownChain, expectedErr: ErrBlockchainNotFound},
+		{name: "empty ID", blkChainID: ids.Empty, cli: &blockchainStatusClient{status: pstatus.Validating}, expected: pstatus.UnknownChain, expectedErr: ErrEmptyID},
+		{name: "node error", blkChainID: ids.ID{1}, cli: &blockchainStatusClient{err: errUnreachable}, expected: pstatus.UnknownChain, expectedErr: errUnreachable},
+	}
+	for i, tv := range tt {
+		pc := &p{cli: tv.cli}
+		status, err := pc.GetBlockchainStatus(context.Background(), tv.blkChainID)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+		if status != tv.expected {
+			t.Fatalf("#%d(%s): unexpected status %s, expected %s", i, tv.name, status, tv.expected)
+		}
+	}
+}
+
+// refetchClient fails the UTXO fetches with [errs], then returns no UTXOs
+// for the next [empty] fetches, then [utxos], and counts the fetches.
+type refetchClient struct {
+	platformvm.Client
+	errs  []error
+	empty int
+	utxos [][]byte
+	calls int
+}
+
+func (c *refetchClient) GetAtomicUTXOs(context.Context, []string, string, uint32, string, string, ...rpc.Option) ([][]byte, api.Index, error) {
+	c.calls++
+	if c.calls <= len(c.errs) {
+		return nil, api.Index{}, c.errs[c.calls-1]
+	}
+	if c.calls <= len(c.errs)+c.empty {
+		return nil, api.Index{}, nil
+	}
+	return c.utxos, api.Index{}, nil
+}
+
+func TestUTXORefetchRetry(t *testing.T) {
+	t.Parallel()
+
+	k, _ := newTestStaker(t)
+	ubs, err := clienttest.UTXOBytes(clienttest.UTXO(
+		clienttest.WithAssetID(ids.ID{1}),
+		clienttest.WithTxID(ids.ID{2}),
+		clienttest.WithOwners(1, k.Address()),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errUnavailable := errors.New("received status code: 503")
+
+	tt := []struct {
+		name          string
+		retry         bool
+		cli           *refetchClient
+		expectedUTXOs int
+		expectedCalls int
+		expectedErr   error
+	}{
+		{name: "found", retry: true, cli: &refetchClient{utxos: ubs}, expectedUTXOs: 1, expectedCalls: 1},
+		{name: "empty then found", retry: true, cli: &refetchClient{empty: 2, utxos: ubs}, expectedUTXOs: 1, expectedCalls: 3},
+		{name: "empty without retry", cli: &refetchClient{empty: 2, utxos: ubs}, expectedCalls: 1},
+		{name: "always empty", retry: true, cli: &refetchClient{empty: 100}, expectedCalls: 1 + utxoRefetchAttempts},
+		{name: "transient error then found", retry: true, cli: &refetchClient{errs: []error{errUnavailable}, utxos: ubs}, expectedUTXOs: 1, expectedCalls: 2},
+		{name: "transient error without retry", cli: &refetchClient{errs: []error{errUnavailable}, utxos: ubs}, expectedCalls: 1, expectedErr: errUnavailable},
+		{name: "permanent error", retry: true, cli: &refetchClient{errs: []error{ErrEmptyID}, utxos: ubs}, expectedCalls: 1, expectedErr: ErrEmptyID},
+	}
+	for i, tv := range tt {
+		pc := &p{cfg: Config{PollInterval: time.Millisecond}, cli: tv.cli}
+		utxos, err := pc.getUTXOs(context.Background(), k, &Op{utxoRefetchRetry: tv.retry})
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+		if len(utxos) != tv.expectedUTXOs {
+			t.Fatalf("#%d(%s): unexpected %d UTXOs, expected %d", i, tv.name, len(utxos), tv.expectedUTXOs)
+		}
+		if tv.cli.calls != tv.expectedCalls {
+			t.Fatalf("#%d(%s): unexpected %d fetches, expected %d", i, tv.name, tv.cli.calls, tv.expectedCalls)
+		}
+	}
+}
+
+// pagedUTXOClient serves [utxos] as the UTXOs of any address, in a
+// single page.
+type pagedUTXOClient struct {
+	platformvm.Client
+	utxos [][]byte
+}
+
+func (c *pagedUTXOClient) GetUTXOs(context.Context, []string, uint32, string, string, ...rpc.Option) ([][]byte, api.Index, error) {
+	return c.utxos, api.Index{}, nil
+}
+
+func TestHasBalance(t *testing.T) {
+	t.Parallel()
+
+	k, pc := newTestStaker(t)
+	now := uint64(time.Now().Unix())
+	ubs, err := clienttest.UTXOBytes(
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{2}),
+			clienttest.WithAmount(units.Djtx),
+			clienttest.WithOwners(1, k.Address()),
+		),
+		// stakeable-locked, so not spendable
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{3}),
+			clienttest.WithAmount(10*units.Djtx),
+			clienttest.WithLocktime(now+3600),
+			clienttest.WithOwners(1, k.Address()),
+		),
+		// stakeable-lock expired, so spendable
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{4}),
+			clienttest.WithAmount(units.Djtx),
+			clienttest.WithLocktime(now-3600),
+			clienttest.WithOwners(1, k.Address()),
+		),
+		// not DJTX
+		clienttest.UTXO(
+			clienttest.WithAssetID(ids.ID{9}),
+			clienttest.WithTxID(ids.ID{5}),
+			clienttest.WithAmount(10*units.Djtx),
+			clienttest.WithOwners(1, k.Address()),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc.cli = &pagedUTXOClient{utxos: ubs}
+
+	tt := []struct {
+		atLeast  uint64
+		expected bool
+	}{
+		{atLeast: 0, expected: true},
+		{atLeast: units.Djtx, expected: true},
+		{atLeast: 2 * units.Djtx, expected: true},
+		{atLeast: 2*units.Djtx + 1, expected: false},
+		{atLeast: 12 * units.Djtx, expected: false},
+	}
+	for i, tv := range tt {
+		ok, err := pc.HasBalance(context.Background(), k, tv.atLeast)
+		if err != nil {
+			t.Fatalf("#%d(%d): %v", i, tv.atLeast, err)
+		}
+		if ok != tv.expected {
+			t.Fatalf("#%d(%d): unexpected HasBalance %v, expected %v", i, tv.atLeast, ok, tv.expected)
+		}
+	}
+}
+
+func TestValidatorAdded(t *testing.T) {
+	t.Parallel()
+
+	nodeID, stakeAmt := ids.ShortID{1}, uint64(2000)
+	start := time.Unix(1_000_000, 0)
+	end := start.Add(24 * time.Hour)
+	validator := clienttest.Validators(clienttest.Validator(nodeID, start, end, stakeAmt, true))
+
+	tt := []struct {
+		name        string
+		current     []interface{}
+		pending     []interface{}
+		expected    bool
+		expectedErr error
+	}{
+		{name: "current", current: validator, expected: true},
+		{name: "pending", pending: validator, expected: true},
+		{name: "not found"},
+		{
+			name:        "mismatched end",
+			current:     clienttest.Validators(clienttest.Validator(nodeID, start, end.Add(time.Hour), stakeAmt, true)),
+			expectedErr: ErrAlreadyValidator,
+		},
+		{
+			name:        "mismatched stake",
+			pending:     clienttest.Validators(clienttest.Validator(nodeID, start, end, stakeAmt+1, true)),
+			expectedErr: ErrAlreadyValidator,
+		},
+	}
+	for i, tv := range tt {
+		pc := &p{cli: &validatorsClient{current: tv.current, pending: tv.pending}}
+		added, err := pc.validatorAdded(context.Background(), nodeID, end, stakeAmt)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+		if added != tv.expected {
+			t.Fatalf("#%d(%s): unexpected added %v, expected %v", i, tv.name, added, tv.expected)
+		}
+	}
+
+	// a retry of the add is a no-op, but not a silent one
+	k, _ := newTestStaker(t)
+	pc := &p{networkID: 12345, cli: &validatorsClient{current: validator}}
+	var warnings []Warning
+	took, err := pc.AddValidator(context.Background(), k, nodeID, start, end,
+		WithStakeAmount(stakeAmt),
+		WithRewardShares(0),
+		WithRewardAddress(k.Address()),
+		WithChangeAddress(k.Address()),
+		WithWarnings(&warnings),
+	)
+	if err != nil || took != 0 {
+		t.Fatalf("unexpected add after %v (%v), expected a no-op", took, err)
+	}
+	if len(warnings) != 1 || warnings[0].Code != WarnValidatorAlreadyAdded {
+		t.Fatalf("unexpected warnings %+v, expected %q", warnings, WarnValidatorAlreadyAdded)
+	}
+}
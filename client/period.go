@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StakingPeriodSummary returns a human-readable summary of the
+// staking period (e.g., "validates for 14 days 3 hours, from
+// 2022-04-01T00:00:00Z to 2022-04-15T03:00:00Z").
+func StakingPeriodSummary(start, end time.Time) string {
+	return fmt.Sprintf("validates for %s, from %s to %s",
+		FormatStakingDuration(end.Sub(start)),
+		start.UTC().Format(time.RFC3339),
+		end.UTC().Format(time.RFC3339),
+	)
+}
+
+// FormatStakingDuration formats [d] in days, hours, and minutes
+// (e.g., "14 days 3 hours"), omitting the zero units.
+func FormatStakingDuration(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	units := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+	}
+	parts := make([]string, 0, len(units))
+	for _, u := range units {
+		n := d / u.d
+		if n == 0 {
+			continue
+		}
+		d -= n * u.d
+		if n == 1 {
+			parts = append(parts, fmt.Sprintf("1 %s", u.name))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d %ss", n, u.name))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+// Phases of "CreateBlockchain", in order.
+const (
+	PhaseAuthorize     = "authorize"
+	PhaseSelectUTXOs   = "select-utxos"
+	PhaseSign          = "sign"
+	PhaseIssue         = "issue"
+	PhasePollStatus    = "poll-status"
+	PhasePollBootstrap = "poll-bootstrap"
+)
+
+// To be called back as "CreateBlockchain" enters each phase (e.g., to
+// render a progress bar in a GUI), with details such as the tx ID.
+// The poll phases are only reached with "WithPoll(true)", and
+// "PhasePollBootstrap" may take minutes.
+func WithPhaseCallback(f func(phase string, detail map[string]interface{})) OpOption {
+	return func(op *Op) {
+		op.phaseCallback = f
+	}
+}
+
+// phase calls back "WithPhaseCallback", if set.
+func (op *Op) phase(name string, detail map[string]interface{}) {
+	if op.phaseCallback != nil {
+		op.phaseCallback(name, detail)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/rpc"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+
+	"github.com/lasthyphen/subnet-cli/client/clienttest"
+	internal_platformvm "github.com/lasthyphen/subnet-cli/internal/platformvm"
+)
+
+// vmsInfo reports the fee of "feeInfo", and [vmID] as installed.
+type vmsInfo struct {
+	feeInfo
+	vmID ids.ID
+}
+
+func (i vmsInfo) GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error) {
+	return map[ids.ID][]string{i.vmID: nil}, nil
+}
+
+// bootstrappedChecker reports any tx committed and any blockchain
+// bootstrapped.
+type bootstrappedChecker struct {
+	committedChecker
+}
+
+func (bootstrappedChecker) PollBlockchain(context.Context, ...internal_platformvm.OpOption) (time.Duration, error) {
+	return 0, nil
+}
+
+func TestCreateBlockchainPhases(t *testing.T) {
+	t.Parallel()
+
+	vmID := ids.ID{7}
+	tt := []struct {
+		name     string
+		poll     bool
+		expected []string
+	}{
+		{
+			name:     "without poll",
+			expected: []string{PhaseAuthorize, PhaseSelectUTXOs, PhaseSign, PhaseIssue},
+		},
+		{
+			name:     "with poll",
+			poll:     true,
+			expected: []string{PhaseAuthorize, PhaseSelectUTXOs, PhaseSign, PhaseIssue, PhasePollStatus},
+		},
+	}
+	for i, tv := range tt {
+		k, pc := newTestStaker(t)
+		pc.cli = &planClient{subnetTx: newTestSubnetTx(t, k, pc)}
+		pc.info, pc.checker = vmsInfo{vmID: vmID}, bootstrappedChecker{}
+		utxos := []*djtx.UTXO{
+			clienttest.UTXO(
+				clienttest.WithAssetID(pc.assetID),
+				clienttest.WithTxID(ids.ID{2}),
+				clienttest.WithAmount(units.Djtx),
+				clienttest.WithOwners(1, k.Address()),
+			),
+		}
+
+		var phases []string
+		_, _, _, err := pc.CreateBlockchain(
+			context.Background(),
+			k,
+			ids.ID{1},
+			"test",
+			vmID,
+			[]byte("genesis"),
+			WithUTXOs(utxos),
+			WithPoll(tv.poll),
+			WithPhaseCallback(func(phase string, detail map[string]interface{}) {
+				if len(detail) == 0 {
+					t.Fatalf("#%d(%s): no detail for phase %q", i, tv.name, phase)
+				}
+				phases = append(phases, phase)
+			}),
+		)
+		if err != nil {
+			t.Fatalf("#%d(%s): %v", i, tv.name, err)
+		}
+		if !reflect.DeepEqual(phases, tv.expected) {
+			t.Fatalf("#%d(%s): unexpected phases %v, expected %v", i, tv.name, phases, tv.expected)
+		}
+	}
+}
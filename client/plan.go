@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	pstatus "github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
+
+	"github.com/lasthyphen/subnet-cli/internal/key"
+)
+
+var ErrPlanNotSigned = errors.New("plan not signed")
+
+// TxPlan is a tx built but not yet signed nor issued, to be inspected
+// (e.g., in a custom UI) before "SignPlan" and "IssuePlan". Only adding a
+// subnet validator is planned ("BuildAddSubnetValidator"), as the other
+// operations also poll for the subnet or blockchain they create.
+type TxPlan struct {
+	// Ins is the selected inputs.
+	Ins []*djtx.TransferableInput
+	// Outs is the change outputs.
+	Outs []*djtx.TransferableOutput
+	// Signers is the distinct addresses whose signatures are required,
+	// for the inputs and the subnet auth.
+	Signers []ids.ShortID
+	// Fee is the nDJTX burned by the tx.
+	Fee uint64
+	// UnsignedTx is the tx to sign.
+	UnsignedTx platformvm.UnsignedTx
+	// NumSigs is the number of credentials of the tx.
+	NumSigs int
+
+	tx    *platformvm.Tx
+	pAddr string
+	ret   *Op
+}
+
+// Signed returns true once the plan is signed.
+func (tp *TxPlan) Signed() bool {
+	return tp.tx != nil
+}
+
+func (pc *p) SignPlan(ctx context.Context, k key.Key, plan *TxPlan) error {
+	pTx := &platformvm.Tx{
+		UnsignedTx: plan.UnsignedTx,
+	}
+	if err := k.Sign(ctx, pTx, plan.NumSigs); err != nil {
+		return err
+	}
+	if err := pc.syntacticVerify(plan.ret, plan.UnsignedTx); err != nil {
+		return err
+	}
+	plan.tx = pTx
+	plan.pAddr = k.P()
+	return nil
+}
+
+func (pc *p) IssuePlan(ctx context.Context, plan *TxPlan) (took time.Duration, err error) {
+	if !plan.Signed() {
+		return 0, ErrPlanNotSigned
+	}
+	if err := pc.checkSnapshot(ctx, plan.ret, plan.pAddr, plan.tx); err != nil {
+		return 0, err
+	}
+	if err := pc.preflight(ctx, plan.ret, plan.pAddr, plan.tx); err != nil {
+		return 0, err
+	}
+	txID, err := pc.cli.IssueTx(ctx, plan.tx.Bytes())
+	pc.audit(plan.ret, plan.pAddr, plan.tx, plan.Fee, err)
+	if err != nil {
+		return 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+
+	return pc.checker.PollTx(ctx, txID, pstatus.Committed)
+}
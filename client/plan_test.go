@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	api_info "github.com/lasthyphen/dijetsnodego/api/info"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/json"
+	"github.com/lasthyphen/dijetsnodego/utils/rpc"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	pstatus "github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+
+	"github.com/lasthyphen/subnet-cli/client/clienttest"
+	"github.com/lasthyphen/subnet-cli/internal/key"
+	internal_platformvm "github.com/lasthyphen/subnet-cli/internal/platformvm"
+)
+
+// planClient serves a primary network validator [nodeID] over [start,
+// end], the subnet creation tx [subnetTx], and records the issued txs.
+type planClient struct {
+	platformvm.Client
+	nodeID     ids.ShortID
+	start, end time.Time
+	subnetTx   []byte
+	issued     [][]byte
+}
+
+func (c *planClient) GetCurrentValidators(_ context.Context, subnetID ids.ID, _ []ids.ShortID, _ ...rpc.Option) ([]interface{}, error) {
+	if subnetID != constants.PrimaryNetworkID {
+		return nil, nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"nodeID":    c.nodeID.PrefixedString(constants.NodeIDPrefix),
+			"startTime": strconv.FormatInt(c.start.Unix(), 10),
+			"endTime":   strconv.FormatInt(c.end.Unix(), 10),
+			"uptime":    "1.0000",
+			"connected": true,
+		},
+	}, nil
+}
+
+func (c *planClient) GetMinStake(context.Context, ids.ID, ...rpc.Option) (uint64, uint64, error) {
+	return 1, 1, nil
+}
+
+func (c *planClient) GetTx(context.Context, ids.ID, ...rpc.Option) ([]byte, error) {
+	return c.subnetTx, nil
+}
+
+func (c *planClient) IssueTx(_ context.Context, b []byte, _ ...rpc.Option) (ids.ID, error) {
+	c.issued = append(c.issued, b)
+	return ids.ID{9}, nil
+}
+
+type feeInfo struct {
+	api_info.Client
+}
+
+func (feeInfo) GetTxFee(context.Context, ...rpc.Option) (*api_info.GetTxFeeResponse, error) {
+	return &api_info.GetTxFeeResponse{TxFee: json.Uint64(units.MilliDjtx)}, nil
+}
+
+type committedChecker struct {
+	internal_platformvm.Checker
+}
+
+func (committedChecker) PollTx(context.Context, ids.ID, pstatus.Status) (time.Duration, error) {
+	return 0, nil
+}
+
+// newTestSubnetTx returns the signed bytes of a subnet creation tx owned
+// by [k] alone.
+func newTestSubnetTx(t *testing.T, k key.Key, pc *p) []byte {
+	t.Helper()
+
+	subnetTx := &platformvm.Tx{
+		UnsignedTx: &platformvm.UnsignedCreateSubnetTx{
+			BaseTx: platformvm.BaseTx{BaseTx: djtx.BaseTx{NetworkID: pc.networkID}},
+			Owner:  &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{k.Address()}},
+		},
+	}
+	if err := k.Sign(context.Background(), subnetTx, 0); err != nil {
+		t.Fatal(err)
+	}
+	return subnetTx.Bytes()
+}
+
+// Builds, signs, and issues a subnet validator through the plan API.
+func TestPlanRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	k, pc := newTestStaker(t)
+	now := time.Now().Truncate(time.Second)
+	cli := &planClient{
+		nodeID:   ids.ShortID{5},
+		start:    now.Add(-time.Hour),
+		end:      now.Add(30 * 24 * time.Hour),
+		subnetTx: newTestSubnetTx(t, k, pc),
+	}
+	pc.cli, pc.info, pc.checker = cli, feeInfo{}, committedChecker{}
+
+	// two inputs owned by the same key, so a single distinct signer
+	utxos := []*djtx.UTXO{
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{2}),
+			clienttest.WithAmount(units.MilliDjtx/2),
+			clienttest.WithOwners(1, k.Address()),
+		),
+		clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(ids.ID{3}),
+			clienttest.WithAmount(units.MilliDjtx/2),
+			clienttest.WithOwners(1, k.Address()),
+		),
+	}
+	plan, err := pc.BuildAddSubnetValidator(
+		context.Background(),
+		k,
+		ids.ID{1},
+		cli.nodeID,
+		time.Time{},
+		time.Time{},
+		1000,
+		WithMatchPrimaryWindow(),
+		WithUTXOs(utxos),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Ins) != 2 || plan.Fee != units.MilliDjtx || plan.NumSigs != 3 {
+		t.Fatalf("unexpected plan %d inputs, fee %d, %d sigs", len(plan.Ins), plan.Fee, plan.NumSigs)
+	}
+	if !reflect.DeepEqual(plan.Signers, []ids.ShortID{k.Address()}) {
+		t.Fatalf("unexpected signers %v, expected %s", plan.Signers, k.Address())
+	}
+
+	if _, err := pc.IssuePlan(context.Background(), plan); !errors.Is(err, ErrPlanNotSigned) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrPlanNotSigned)
+	}
+	if err := pc.SignPlan(context.Background(), k, plan); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pc.IssuePlan(context.Background(), plan); err != nil {
+		t.Fatal(err)
+	}
+	if len(cli.issued) != 1 {
+		t.Fatalf("unexpected %d issued txs, expected 1", len(cli.issued))
+	}
+	tx, err := DecodeTx(cli.issued[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tx.UnsignedTx.(*platformvm.UnsignedAddSubnetValidatorTx); !ok || len(tx.Creds) != plan.NumSigs {
+		t.Fatalf("unexpected issued tx %T with %d credentials", tx.UnsignedTx, len(tx.Creds))
+	}
+}
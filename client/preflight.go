@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+	"github.com/lasthyphen/subnet-cli/internal/key"
+	"go.uber.org/zap"
+)
+
+var ErrPreflightFailed = errors.New("preflight failed")
+
+// To check right before issuing, against the node's current state, that
+// the node won't reject the signed tx, unlike the local syntactic
+// verification. It checks that:
+//   - the inputs are still unspent (e.g., not spent in the meantime by
+//     another tx or a stale snapshot)
+//   - the node isn't already a current or pending validator of the subnet
+//     (e.g., added by another operator since the tx was built)
+//   - the subnet auth signatures satisfy the subnet owner (e.g., a plan
+//     signed with too few or the wrong keys)
+//
+// The P-Chain API has no endpoint to verify a tx without committing it,
+// so this only covers what can be read back from the node. If the node
+// can't be queried, the check is skipped with a warning.
+func WithPreflight(b bool) OpOption {
+	return func(op *Op) {
+		op.preflight = b
+	}
+}
+
+// preflight runs the checks of "WithPreflight" against [pTx], whose
+// inputs spend the UTXOs of the P-Chain address [pAddr].
+func (pc *p) preflight(ctx context.Context, ret *Op, pAddr string, pTx *platformvm.Tx) error {
+	if !ret.preflight {
+		return nil
+	}
+	if err := pc.preflightInputs(ctx, pAddr, pTx); err != nil {
+		return err
+	}
+	switch utx := pTx.UnsignedTx.(type) {
+	case *platformvm.UnsignedAddValidatorTx:
+		if err := pc.preflightValidator(ctx, ids.Empty, utx.Validator.NodeID); err != nil {
+			return err
+		}
+	case *platformvm.UnsignedAddSubnetValidatorTx:
+		if err := pc.preflightValidator(ctx, utx.Validator.Subnet, utx.Validator.NodeID); err != nil {
+			return err
+		}
+		if err := pc.preflightAuth(ctx, utx.Validator.Subnet, utx.SubnetAuth, pTx); err != nil {
+			return err
+		}
+	case *platformvm.UnsignedCreateChainTx:
+		if err := pc.preflightAuth(ctx, utx.SubnetID, utx.SubnetAuth, pTx); err != nil {
+			return err
+		}
+	}
+	zap.L().Info("preflight passed", zap.String("txId", pTx.ID().String()))
+	return nil
+}
+
+// preflightInputs checks that the inputs of [pTx] are still unspent UTXOs
+// of [pAddr].
+func (pc *p) preflightInputs(ctx context.Context, pAddr string, pTx *platformvm.Tx) error {
+	ubs, err := pc.fetchUTXOs(ctx, pAddr)
+	if err != nil {
+		// only a safety net, so don't block issuing
+		zap.L().Warn("failed to fetch UTXOs, skipping preflight input check", zap.Error(err))
+		return nil
+	}
+	utxos, err := parseUTXOs(ubs)
+	if err != nil {
+		return err
+	}
+	unspent := make(map[ids.ID]struct{}, len(utxos))
+	for _, utxo := range utxos {
+		unspent[utxo.InputID()] = struct{}{}
+	}
+	for inputID := range pTx.UnsignedTx.InputIDs() {
+		if _, ok := unspent[inputID]; !ok {
+			return fmt.Errorf("%w: input %s is spent or unknown to the node", ErrPreflightFailed, inputID)
+		}
+	}
+	return nil
+}
+
+// preflightValidator checks that [nodeID] is neither a current nor a
+// pending validator of [subnetID].
+func (pc *p) preflightValidator(ctx context.Context, subnetID ids.ID, nodeID ids.ShortID) error {
+	_, _, err := pc.GetValidator(ctx, subnetID, nodeID)
+	if errors.Is(err, ErrValidatorNotFound) {
+		_, _, err = pc.GetPendingValidator(ctx, subnetID, nodeID)
+	}
+	switch {
+	case errors.Is(err, ErrValidatorNotFound):
+		return nil
+	case err != nil:
+		zap.L().Warn("failed to get validator, skipping preflight validator check", zap.Error(err))
+		return nil
+	}
+	return fmt.Errorf("%w: %v (%s on subnet %s)", ErrPreflightFailed, ErrAlreadyValidator, nodeID, subnetID)
+}
+
+// preflightAuth checks that the subnet auth of [pTx], signed by its last
+// credential, satisfies the owner of [subnetID].
+func (pc *p) preflightAuth(ctx context.Context, subnetID ids.ID, auth interface{}, pTx *platformvm.Tx) error {
+	owner, err := pc.subnetOwner(ctx, subnetID)
+	if err != nil {
+		zap.L().Warn("failed to get subnet owner, skipping preflight auth check", zap.Error(err))
+		return nil
+	}
+	return verifySubnetAuth(owner, auth, pTx)
+}
+
+// verifySubnetAuth mirrors the node's "secp256k1fx.Fx.VerifyCredentials"
+// for the subnet auth [auth] of [pTx] against [owner].
+func verifySubnetAuth(owner *secp256k1fx.OutputOwners, auth interface{}, pTx *platformvm.Tx) error {
+	in, ok := auth.(*secp256k1fx.Input)
+	if !ok {
+		return fmt.Errorf("%w: unexpected subnet auth %T", ErrPreflightFailed, auth)
+	}
+	if uint32(len(in.SigIndices)) != owner.Threshold {
+		return fmt.Errorf("%w: subnet auth has %d signers (expected threshold %d)", ErrPreflightFailed, len(in.SigIndices), owner.Threshold)
+	}
+	if len(pTx.Creds) == 0 {
+		return fmt.Errorf("%w: missing subnet auth credential", ErrPreflightFailed)
+	}
+	cred, ok := pTx.Creds[len(pTx.Creds)-1].(*secp256k1fx.Credential)
+	if !ok || len(cred.Sigs) != len(in.SigIndices) {
+		return fmt.Errorf("%w: subnet auth credential doesn't match its %d signers", ErrPreflightFailed, len(in.SigIndices))
+	}
+	hash, _, err := key.SigningHash(pTx.UnsignedTx)
+	if err != nil {
+		return err
+	}
+	factory := &crypto.FactorySECP256K1R{}
+	for i, index := range in.SigIndices {
+		if index >= uint32(len(owner.Addrs)) {
+			return fmt.Errorf("%w: subnet auth signer index %d out of bounds", ErrPreflightFailed, index)
+		}
+		pk, err := factory.RecoverHashPublicKey(hash, cred.Sigs[i][:])
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrPreflightFailed, err)
+		}
+		if expected := owner.Addrs[index]; pk.Address() != expected {
+			return fmt.Errorf("%w: subnet auth signed by %s (expected %s)", ErrPreflightFailed, pk.Address(), expected)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lasthyphen/dijetsnodego/api"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/rpc"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+
+	"github.com/lasthyphen/subnet-cli/client/clienttest"
+	"github.com/lasthyphen/subnet-cli/internal/key"
+)
+
+// utxosClient serves [utxos] as the UTXOs of any address, or fails with
+// [err].
+type utxosClient struct {
+	platformvm.Client
+	utxos [][]byte
+	err   error
+}
+
+func (c *utxosClient) GetAtomicUTXOs(context.Context, []string, string, uint32, string, string, ...rpc.Option) ([][]byte, api.Index, error) {
+	return c.utxos, api.Index{}, c.err
+}
+
+func TestPreflightInputs(t *testing.T) {
+	t.Parallel()
+
+	k, pc := newTestStaker(t)
+	ubs, err := clienttest.UTXOBytes(clienttest.UTXO(
+		clienttest.WithAssetID(pc.assetID),
+		clienttest.WithTxID(ids.ID{2}),
+		clienttest.WithOwners(1, k.Address()),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc.cli = &utxosClient{utxos: ubs}
+
+	input := func(txID ids.ID) *djtx.TransferableInput {
+		return &djtx.TransferableInput{
+			UTXOID: djtx.UTXOID{TxID: txID},
+			Asset:  djtx.Asset{ID: pc.assetID},
+			In:     &secp256k1fx.TransferInput{Amt: 1, Input: secp256k1fx.Input{SigIndices: []uint32{0}}},
+		}
+	}
+	tt := []struct {
+		name        string
+		ins         []*djtx.TransferableInput
+		preflight   bool
+		expectedErr error
+	}{
+		{name: "unspent", ins: []*djtx.TransferableInput{input(ids.ID{2})}, preflight: true},
+		{name: "spent", ins: []*djtx.TransferableInput{input(ids.ID{2}), input(ids.ID{3})}, preflight: true, expectedErr: ErrPreflightFailed},
+		{name: "disabled", ins: []*djtx.TransferableInput{input(ids.ID{3})}},
+	}
+	for i, tv := range tt {
+		pTx := &platformvm.Tx{
+			UnsignedTx: &platformvm.UnsignedCreateSubnetTx{
+				BaseTx: platformvm.BaseTx{BaseTx: djtx.BaseTx{NetworkID: pc.networkID, Ins: tv.ins}},
+				Owner:  &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{k.Address()}},
+			},
+		}
+		err := pc.preflight(context.Background(), &Op{preflight: tv.preflight}, k.P(), pTx)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+	}
+}
+
+func TestVerifySubnetAuth(t *testing.T) {
+	t.Parallel()
+
+	k1, err := key.NewSoft(12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := key.NewSoft(12345)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{k1.Address()}}
+	auth, err := authorizeOwner(owner, k1, ids.ShortEmpty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name        string
+		signer      key.Key
+		owner       *secp256k1fx.OutputOwners
+		expectedErr error
+	}{
+		{name: "owner", signer: k1, owner: owner},
+		{name: "not the owner", signer: k2, owner: owner, expectedErr: ErrPreflightFailed},
+		{
+			name:        "below threshold",
+			signer:      k1,
+			owner:       &secp256k1fx.OutputOwners{Threshold: 2, Addrs: []ids.ShortID{k1.Address(), k2.Address()}},
+			expectedErr: ErrPreflightFailed,
+		},
+	}
+	for i, tv := range tt {
+		pTx := &platformvm.Tx{
+			UnsignedTx: &platformvm.UnsignedAddSubnetValidatorTx{SubnetAuth: auth},
+		}
+		if err := tv.signer.Sign(context.Background(), pTx, 1); err != nil {
+			t.Fatal(err)
+		}
+		err := verifySubnetAuth(tv.owner, auth, pTx)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"fmt"
+
+	api_info "github.com/lasthyphen/dijetsnodego/api/info"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/math"
+)
+
+// Operation is a P-chain operation issued by this client.
+type Operation uint8
+
+const (
+	OperationCreateSubnet Operation = iota
+	OperationAddValidator
+	OperationAddSubnetValidator
+	OperationCreateBlockchain
+)
+
+func (o Operation) String() string {
+	switch o {
+	case OperationCreateSubnet:
+		return "create-subnet"
+	case OperationAddValidator:
+		return "add-validator"
+	case OperationAddSubnetValidator:
+		return "add-subnet-validator"
+	case OperationCreateBlockchain:
+		return "create-blockchain"
+	default:
+		return "unknown"
+	}
+}
+
+// Staking returns true if [o] locks a stake in addition to burning a fee.
+func (o Operation) Staking() bool {
+	return o == OperationAddValidator
+}
+
+// Fee returns the nDJTX burned by a single [op] from the fee schedule
+// reported by the node, so networks with custom fees (e.g., local
+// networks) are handled the same as the public ones.
+func Fee(fees *api_info.GetTxFeeResponse, op Operation) uint64 {
+	switch op {
+	case OperationCreateSubnet:
+		return uint64(fees.CreateSubnetTxFee)
+	case OperationAddValidator:
+		// pre-Banff nodes don't report it, as adding a validator burns
+		// the staker fee that defaults to 0
+		return uint64(fees.AddPrimaryNetworkValidatorFee)
+	case OperationAddSubnetValidator:
+		// pre-Banff nodes don't report it, and burn the base tx fee
+		if fees.AddSubnetValidatorFee == 0 {
+			return uint64(fees.TxFee)
+		}
+		return uint64(fees.AddSubnetValidatorFee)
+	case OperationCreateBlockchain:
+		return uint64(fees.CreateBlockchainTxFee)
+	default:
+		return uint64(fees.TxFee)
+	}
+}
+
+// ZeroFees returns the fees of [fees] that are zero while [networkID]
+// (mainnet or tahoe) is expected to charge them, which indicates a
+// misconfigured node. Local and custom networks may be fee-free, so
+// it returns none for them.
+func ZeroFees(networkID uint32, fees *api_info.GetTxFeeResponse) []string {
+	if networkID != constants.MainnetID && networkID != constants.TahoeID {
+		return nil
+	}
+	var zero []string
+	if fees.TxFee == 0 {
+		zero = append(zero, "txFee")
+	}
+	if fees.CreateSubnetTxFee == 0 {
+		zero = append(zero, "createSubnetTxFee")
+	}
+	if fees.CreateBlockchainTxFee == 0 {
+		zero = append(zero, "createBlockchainTxFee")
+	}
+	return zero
+}
+
+// RequiredBalance returns the total nDJTX a key must hold to issue a single
+// [op], given the burned [fee] and, for staking operations, the [stakeAmt].
+// It mirrors what "stake" consumes, so the amount shown to users matches
+// what the transaction actually requires. [stakeAmt] is ignored for
+// operations that do not stake. Fails with "ErrAmountOverflow" if the
+// total doesn't fit in uint64.
+func RequiredBalance(op Operation, fee uint64, stakeAmt uint64) (uint64, error) {
+	if !op.Staking() {
+		return fee, nil
+	}
+	return AddAmounts(fee, stakeAmt)
+}
+
+// RequiredBalanceN returns the total nDJTX to issue [n] of [op],
+// each with the same [fee] and [stakeAmt] (e.g., one per node).
+// Fails with "ErrNegativeCount" if [n] is negative.
+func RequiredBalanceN(op Operation, fee uint64, stakeAmt uint64, n int) (uint64, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("%w: %d", ErrNegativeCount, n)
+	}
+	required, err := RequiredBalance(op, fee, stakeAmt)
+	if err != nil {
+		return 0, err
+	}
+	total, err := math.Mul64(required, uint64(n))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %d x %d", ErrAmountOverflow, required, n)
+	}
+	return total, nil
+}
+
+// AddAmounts sums the nDJTX [amounts], failing with "ErrAmountOverflow"
+// instead of wrapping around.
+func AddAmounts(amounts ...uint64) (uint64, error) {
+	total := uint64(0)
+	for _, amount := range amounts {
+		sum, err := math.Add64(total, amount)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %d + %d", ErrAmountOverflow, total, amount)
+		}
+		total = sum
+	}
+	return total, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	api_info "github.com/lasthyphen/dijetsnodego/api/info"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+)
+
+func TestFee(t *testing.T) {
+	t.Parallel()
+
+	// a local network with non-standard fees, before Banff
+	local := &api_info.GetTxFeeResponse{
+		TxFee:                 7,
+		CreateSubnetTxFee:     11,
+		CreateBlockchainTxFee: 13,
+	}
+	// the same network, reporting the per-staker fees
+	banff := &api_info.GetTxFeeResponse{
+		TxFee:                         7,
+		CreateSubnetTxFee:             11,
+		CreateBlockchainTxFee:         13,
+		AddPrimaryNetworkValidatorFee: 17,
+		AddSubnetValidatorFee:         19,
+	}
+	tt := []struct {
+		fees        *api_info.GetTxFeeResponse
+		op          Operation
+		expectedFee uint64
+	}{
+		{fees: local, op: OperationCreateSubnet, expectedFee: 11},
+		{fees: local, op: OperationAddValidator, expectedFee: 0},
+		{fees: local, op: OperationAddSubnetValidator, expectedFee: 7},
+		{fees: local, op: OperationCreateBlockchain, expectedFee: 13},
+		{fees: banff, op: OperationAddValidator, expectedFee: 17},
+		{fees: banff, op: OperationAddSubnetValidator, expectedFee: 19},
+	}
+	for i, tv := range tt {
+		if fee := Fee(tv.fees, tv.op); fee != tv.expectedFee {
+			t.Fatalf("#%d(%s): unexpected fee %d, expected %d", i, tv.op, fee, tv.expectedFee)
+		}
+	}
+}
+
+func TestZeroFees(t *testing.T) {
+	t.Parallel()
+
+	free := &api_info.GetTxFeeResponse{CreateSubnetTxFee: 11}
+	tt := []struct {
+		networkID uint32
+		expected  int
+	}{
+		{networkID: constants.MainnetID, expected: 2},
+		{networkID: constants.TahoeID, expected: 2},
+		{networkID: constants.LocalID, expected: 0},
+	}
+	for i, tv := range tt {
+		if zero := ZeroFees(tv.networkID, free); len(zero) != tv.expected {
+			t.Fatalf("#%d(%d): unexpected zero fees %v, expected %d", i, tv.networkID, zero, tv.expected)
+		}
+	}
+}
+
+func FuzzRequiredBalanceN(f *testing.F) {
+	f.Add(uint64(1_000_000), uint64(2_000_000_000_000), uint16(3))
+	f.Add(uint64(math.MaxUint64), uint64(1), uint16(1))
+	f.Add(uint64(1), uint64(math.MaxUint64/2), uint16(2))
+	f.Fuzz(func(t *testing.T, fee uint64, stakeAmt uint64, n uint16) {
+		total, err := RequiredBalanceN(OperationAddValidator, fee, stakeAmt, int(n))
+
+		// compare against the unbounded arithmetic, where a single
+		// operation must already be representable
+		single := new(big.Int).SetUint64(fee)
+		single.Add(single, new(big.Int).SetUint64(stakeAmt))
+		expected := new(big.Int).Mul(single, big.NewInt(int64(n)))
+		if !single.IsUint64() || !expected.IsUint64() {
+			if !errors.Is(err, ErrAmountOverflow) {
+				t.Fatalf("unexpected error %v for %s, expected %v", err, expected, ErrAmountOverflow)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("unexpected error %v for %s", err, expected)
+		}
+		if total != expected.Uint64() {
+			t.Fatalf("unexpected total %d, expected %s", total, expected)
+		}
+	})
+}
+
+func TestRequiredBalanceNNegative(t *testing.T) {
+	t.Parallel()
+
+	if _, err := RequiredBalanceN(OperationAddValidator, 1, 1, -1); !errors.Is(err, ErrNegativeCount) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrNegativeCount)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// terminalMessages are the substrings of the node errors that won't
+// change by retrying (e.g., tx verification failures).
+var terminalMessages = []string{
+	"insufficient",
+	"already",
+	"duplicate",
+	"conflict",
+	"invalid",
+	"failed verification",
+	"not enough",
+	"unauthorized",
+}
+
+// retryable returns true if [err] is transient (e.g., a network failure,
+// a 5xx status, or the deadline of a single attempt), so that the same
+// request may succeed if retried. Validation failures, insufficient funds,
+// duplicate txs, and any unknown error are terminal, so that retrying
+// never risks paying a fee twice for a request the node rejected.
+// All the retry sites share it, to classify the errors consistently.
+func retryable(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, context.Canceled):
+		// cancelled by the caller, e.g., on interrupt
+		return false
+	case errors.Is(err, context.DeadlineExceeded):
+		return true
+	}
+	for _, sentinel := range []error{
+		ErrInsufficientBalanceForGasFee,
+		ErrInsufficientBalanceForStakeAmount,
+		ErrAlreadyValidator,
+		ErrAlreadySubnetValidator,
+		ErrAmountOverflow,
+	} {
+		if errors.Is(err, sentinel) {
+			return false
+		}
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr):
+		return true
+	case errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET):
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, m := range terminalMessages {
+		if strings.Contains(msg, m) {
+			return false
+		}
+	}
+	// ref. "rpc.SendJSONRequest" for the status code error
+	const statusPrefix = "received status code: "
+	if i := strings.Index(msg, statusPrefix); i >= 0 {
+		status := msg[i+len(statusPrefix):]
+		if j := strings.IndexFunc(status, func(r rune) bool { return r < '0' || r > '9' }); j >= 0 {
+			status = status[:j]
+		}
+		code, cerr := strconv.Atoi(status)
+		return cerr == nil && (code >= 500 || code == 429)
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+)
+
+func TestRetryable(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{name: "nil", err: nil},
+		{name: "deadline", err: fmt.Errorf("failed to issue request: %w", context.DeadlineExceeded), retryable: true},
+		{name: "cancelled", err: context.Canceled},
+		{name: "net timeout", err: &net.OpError{Op: "dial", Err: &net.DNSError{IsTimeout: true}}, retryable: true},
+		{name: "connection refused", err: fmt.Errorf("failed to issue request: %w", syscall.ECONNREFUSED), retryable: true},
+		{name: "EOF", err: io.ErrUnexpectedEOF, retryable: true},
+		{name: "503", err: errors.New("received status code: 503"), retryable: true},
+		{name: "wrapped 502", err: fmt.Errorf("failed to issue tx: %w", errors.New("received status code: 502 (bad gateway)")), retryable: true},
+		{name: "429", err: errors.New("received status code: 429"), retryable: true},
+		{name: "404", err: errors.New("received status code: 404")},
+		{name: "insufficient funds", err: ErrInsufficientBalanceForGasFee},
+		{name: "already validator", err: fmt.Errorf("%w (validate end)", ErrAlreadyValidator)},
+		{name: "overflow", err: ErrAmountOverflow},
+		{name: "verification", err: errors.New("failed verification: input has insufficient funds")},
+		{name: "duplicate", err: errors.New("duplicate tx")},
+		{name: "unknown", err: errors.New("something happened")},
+	}
+	for i, tv := range tt {
+		if got := retryable(tv.err); got != tv.retryable {
+			t.Fatalf("#%d(%s): unexpected retryable %v, expected %v", i, tv.name, got, tv.retryable)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/subnet-cli/internal/key"
+)
+
+// RewardPosition is the potential reward of a single primary network
+// validation or delegation, paid at [End] if the validator meets the
+// uptime requirement.
+type RewardPosition struct {
+	NodeID    ids.ShortID
+	Delegator bool
+	End       time.Time
+	Stake     uint64
+	Reward    uint64
+}
+
+func (pc *p) PendingRewards(ctx context.Context, k key.Key) (uint64, []RewardPosition, error) {
+	vs, err := pc.Client().GetCurrentValidators(ctx, constants.PrimaryNetworkID, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	return pendingRewards(vs, k.P())
+}
+
+// pendingRewards sums the potential rewards of the validations and
+// delegations in the "GetCurrentValidators" response [vs] that reward
+// the formatted P-Chain address [pAddr].
+func pendingRewards(vs []interface{}, pAddr string) (total uint64, positions []RewardPosition, err error) {
+	for _, v := range vs {
+		va, ok := v.(map[string]interface{})
+		if !ok {
+			return 0, nil, fmt.Errorf("%w: %T %+v", ErrInvalidValidatorData, v, v)
+		}
+		records := []map[string]interface{}{va}
+		if ds, ok := va["delegators"].([]interface{}); ok {
+			for _, d := range ds {
+				da, ok := d.(map[string]interface{})
+				if !ok {
+					return 0, nil, fmt.Errorf("%w: %T %+v", ErrInvalidValidatorData, d, d)
+				}
+				records = append(records, da)
+			}
+		}
+		for i, r := range records {
+			if !rewardsTo(r, pAddr) {
+				continue
+			}
+			pos, err := parseRewardPosition(r)
+			if err != nil {
+				return 0, nil, err
+			}
+			pos.Delegator = i > 0
+			total, err = AddAmounts(total, pos.Reward)
+			if err != nil {
+				return 0, nil, err
+			}
+			positions = append(positions, pos)
+		}
+	}
+	return total, positions, nil
+}
+
+// rewardsTo returns true if [pAddr] is one of the reward owners of the
+// validator or delegator record [r].
+func rewardsTo(r map[string]interface{}, pAddr string) bool {
+	owner, ok := r["rewardOwner"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	addrs, ok := owner["addresses"].([]interface{})
+	if !ok {
+		return false
+	}
+	for _, a := range addrs {
+		if s, ok := a.(string); ok && s == pAddr {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRewardPosition(r map[string]interface{}) (RewardPosition, error) {
+	nodeIDs, ok := r["nodeID"].(string)
+	if !ok {
+		return RewardPosition{}, ErrInvalidValidatorData
+	}
+	nodeID, err := ids.ShortFromPrefixedString(nodeIDs, constants.NodeIDPrefix)
+	if err != nil {
+		return RewardPosition{}, err
+	}
+	// of format `json.Uint64`
+	var vals [3]uint64
+	for i, field := range []string{"endTime", "stakeAmount", "potentialReward"} {
+		d, ok := r[field].(string)
+		if !ok {
+			return RewardPosition{}, fmt.Errorf("%w: missing %q", ErrInvalidValidatorData, field)
+		}
+		vals[i], err = strconv.ParseUint(d, 10, 64)
+		if err != nil {
+			return RewardPosition{}, err
+		}
+	}
+	return RewardPosition{
+		NodeID: nodeID,
+		End:    time.Unix(int64(vals[0]), 0),
+		Stake:  vals[1],
+		Reward: vals[2],
+	}, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+)
+
+func TestPendingRewards(t *testing.T) {
+	t.Parallel()
+
+	mine, other := "P-custom1mine", "P-custom1other"
+	n1, n2 := ids.ShortID{1}, ids.ShortID{2}
+	record := func(nodeID ids.ShortID, end int64, stake uint64, reward uint64, owner string) map[string]interface{} {
+		return map[string]interface{}{
+			"nodeID":          nodeID.PrefixedString(constants.NodeIDPrefix),
+			"endTime":         strconv.FormatInt(end, 10),
+			"stakeAmount":     strconv.FormatUint(stake, 10),
+			"potentialReward": strconv.FormatUint(reward, 10),
+			"rewardOwner": map[string]interface{}{
+				"threshold": "1",
+				"addresses": []interface{}{owner},
+			},
+		}
+	}
+	v1 := record(n1, 100, 2000, 10, mine)
+	v1["delegators"] = []interface{}{
+		record(n1, 50, 25, 2, mine),
+		record(n1, 100, 25, 3, other),
+	}
+	v2 := record(n2, 200, 3000, 20, other)
+	v2["delegators"] = []interface{}{record(n2, 150, 30, 5, mine)}
+
+	total, positions, err := pendingRewards([]interface{}{v1, v2}, mine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 17 {
+		t.Fatalf("unexpected total %d, expected 17", total)
+	}
+	expected := []RewardPosition{
+		{NodeID: n1, End: time.Unix(100, 0), Stake: 2000, Reward: 10},
+		{NodeID: n1, Delegator: true, End: time.Unix(50, 0), Stake: 25, Reward: 2},
+		{NodeID: n2, Delegator: true, End: time.Unix(150, 0), Stake: 30, Reward: 5},
+	}
+	if !reflect.DeepEqual(positions, expected) {
+		t.Fatalf("unexpected positions %+v, expected %+v", positions, expected)
+	}
+
+	total, positions, err = pendingRewards([]interface{}{v2}, "P-custom1none")
+	if err != nil || total != 0 || len(positions) != 0 {
+		t.Fatalf("unexpected rewards %d %+v (%v), expected none", total, positions, err)
+	}
+
+	huge := record(n2, 200, 3000, math.MaxUint64, mine)
+	if _, _, err := pendingRewards([]interface{}{v1, huge}, mine); !errors.Is(err, ErrAmountOverflow) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrAmountOverflow)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/subnet-cli/internal/key"
+	"go.uber.org/zap"
+)
+
+const (
+	fsModeWrite = 0o600
+
+	defaultSnapshotTolerance = 10 * time.Minute
+)
+
+var (
+	ErrStaleSnapshot    = errors.New("stale utxo snapshot")
+	ErrSnapshotMismatch = errors.New("utxo snapshot mismatch")
+)
+
+// UTXOSnapshot is the UTXO set of an address at a point in time,
+// so that txs can be built on a machine without node access.
+type UTXOSnapshot struct {
+	NetworkID uint32 `json:"networkId"`
+	Address   string `json:"address"`
+
+	// P-Chain height and time when the UTXOs were fetched
+	Height uint64    `json:"height"`
+	Time   time.Time `json:"time"`
+
+	// hex-encoded UTXO bytes
+	UTXOs []string `json:"utxos"`
+}
+
+// ParseUTXOs returns the decoded UTXOs of the snapshot,
+// to be passed to "WithUTXOs".
+func (s *UTXOSnapshot) ParseUTXOs() ([]*djtx.UTXO, error) {
+	ubs := make([][]byte, len(s.UTXOs))
+	for i, u := range s.UTXOs {
+		ub, err := hex.DecodeString(u)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode utxo #%d: %w", i, err)
+		}
+		ubs[i] = ub
+	}
+	return parseUTXOs(ubs)
+}
+
+// LoadUTXOSnapshot loads the snapshot written by "ExportUTXOSnapshot".
+func LoadUTXOSnapshot(path string) (*UTXOSnapshot, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := new(UTXOSnapshot)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal utxo snapshot: %w", err)
+	}
+	return s, nil
+}
+
+func (pc *p) ExportUTXOSnapshot(ctx context.Context, k key.Key, path string) (*UTXOSnapshot, error) {
+	// fetch the height first, so that the recorded height
+	// is never newer than the fetched UTXOs
+	height, err := pc.cli.GetHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	s := &UTXOSnapshot{
+		NetworkID: pc.networkID,
+		Address:   k.P(),
+		Height:    height,
+		Time:      now,
+		UTXOs:     make([]string, 0),
+	}
+	ubs, err := pc.fetchUTXOs(ctx, k.P())
+	if err != nil {
+		return nil, err
+	}
+	for _, ub := range ubs {
+		s.UTXOs = append(s.UTXOs, hex.EncodeToString(ub))
+	}
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, b, fsModeWrite); err != nil {
+		return nil, err
+	}
+	zap.L().Info("exported utxo snapshot",
+		zap.String("address", s.Address),
+		zap.Uint64("height", s.Height),
+		zap.Int("utxos", len(s.UTXOs)),
+		zap.String("path", path),
+	)
+	return s, nil
+}
+
+// checkSnapshot checks the UTXO snapshot set via "WithUTXOSnapshot"
+// against [pTx] built from it and the current P-Chain state. It fails with
+// "ErrSnapshotMismatch" if the snapshot is of another network or of
+// another address than the signer's [pAddr] (unchecked if empty), and
+// with "ErrStaleSnapshot" if any input of [pTx] from the snapshot has been
+// spent since. If the node can't return the UTXOs, it falls back to
+// rejecting a snapshot older than the tolerance.
+func (pc *p) checkSnapshot(ctx context.Context, ret *Op, pAddr string, pTx *platformvm.Tx) error {
+	s := ret.snapshot
+	if s == nil {
+		return nil
+	}
+	if s.NetworkID != pc.networkID {
+		return fmt.Errorf("%w: network ID %d (expected %d)", ErrSnapshotMismatch, s.NetworkID, pc.networkID)
+	}
+	if pAddr != "" && s.Address != pAddr {
+		return fmt.Errorf("%w: address %s (expected %s)", ErrSnapshotMismatch, s.Address, pAddr)
+	}
+	snapshotUTXOs, err := s.ParseUTXOs()
+	if err != nil {
+		return err
+	}
+
+	ubs, err := pc.fetchUTXOs(ctx, s.Address)
+	if err != nil {
+		tolerance := ret.snapshotTolerance
+		if tolerance == 0 {
+			tolerance = defaultSnapshotTolerance
+		}
+		zap.L().Warn("failed to fetch UTXOs, checking utxo snapshot age only",
+			zap.Duration("tolerance", tolerance),
+			zap.Error(err),
+		)
+		if age := time.Since(s.Time); age > tolerance {
+			return fmt.Errorf("%w: fetched %v ago at height %d (tolerance %v)", ErrStaleSnapshot, age, s.Height, tolerance)
+		}
+		return nil
+	}
+	utxos, err := parseUTXOs(ubs)
+	if err != nil {
+		return err
+	}
+	unspent := make(map[ids.ID]struct{}, len(utxos))
+	for _, utxo := range utxos {
+		unspent[utxo.InputID()] = struct{}{}
+	}
+	inputIDs := pTx.UnsignedTx.InputIDs()
+	for _, utxo := range snapshotUTXOs {
+		inputID := utxo.InputID()
+		if !inputIDs.Contains(inputID) {
+			continue
+		}
+		if _, ok := unspent[inputID]; !ok {
+			return fmt.Errorf("%w: input %s was spent after height %d", ErrStaleSnapshot, inputID, s.Height)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+
+	"github.com/lasthyphen/subnet-cli/client/clienttest"
+)
+
+func TestCheckSnapshot(t *testing.T) {
+	t.Parallel()
+
+	k, pc := newTestStaker(t)
+	utxo := func(txID ids.ID) *djtx.UTXO {
+		return clienttest.UTXO(
+			clienttest.WithAssetID(pc.assetID),
+			clienttest.WithTxID(txID),
+			clienttest.WithOwners(1, k.Address()),
+		)
+	}
+	spent, unspent := utxo(ids.ID{2}), utxo(ids.ID{3})
+	snapshotUTXOs, err := clienttest.UTXOBytes(spent, unspent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	currentUTXOs, err := clienttest.UTXOBytes(unspent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := func(modify func(s *UTXOSnapshot)) *UTXOSnapshot {
+		s := &UTXOSnapshot{NetworkID: pc.networkID, Address: k.P(), Height: 10, Time: time.Now()}
+		for _, ub := range snapshotUTXOs {
+			s.UTXOs = append(s.UTXOs, hex.EncodeToString(ub))
+		}
+		modify(s)
+		return s
+	}
+	spending := func(utxo *djtx.UTXO) *platformvm.Tx {
+		return &platformvm.Tx{
+			UnsignedTx: &platformvm.UnsignedCreateSubnetTx{
+				BaseTx: platformvm.BaseTx{BaseTx: djtx.BaseTx{
+					NetworkID: pc.networkID,
+					Ins: []*djtx.TransferableInput{{
+						UTXOID: utxo.UTXOID,
+						Asset:  utxo.Asset,
+						In:     &secp256k1fx.TransferInput{Amt: 1, Input: secp256k1fx.Input{SigIndices: []uint32{0}}},
+					}},
+				}},
+				Owner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{k.Address()}},
+			},
+		}
+	}
+	old := func(s *UTXOSnapshot) { s.Time = time.Now().Add(-time.Hour) }
+
+	tt := []struct {
+		name        string
+		snapshot    *UTXOSnapshot
+		pAddr       string
+		pTx         *platformvm.Tx
+		fetchErr    error
+		expectedErr error
+	}{
+		{name: "no snapshot", pTx: spending(spent)},
+		{name: "unspent", snapshot: snapshot(func(*UTXOSnapshot) {}), pAddr: k.P(), pTx: spending(unspent)},
+		{name: "unspent and old", snapshot: snapshot(old), pAddr: k.P(), pTx: spending(unspent)},
+		{name: "raw tx", snapshot: snapshot(func(*UTXOSnapshot) {}), pTx: spending(unspent)},
+		{
+			name:        "spent",
+			snapshot:    snapshot(func(*UTXOSnapshot) {}),
+			pAddr:       k.P(),
+			pTx:         spending(spent),
+			expectedErr: ErrStaleSnapshot,
+		},
+		{
+			name:        "network mismatch",
+			snapshot:    snapshot(func(s *UTXOSnapshot) { s.NetworkID = 1337 }),
+			pAddr:       k.P(),
+			pTx:         spending(unspent),
+			expectedErr: ErrSnapshotMismatch,
+		},
+		{
+			name:        "address mismatch",
+			snapshot:    snapshot(func(s *UTXOSnapshot) { s.Address = "P-custom1other" }),
+			pAddr:       k.P(),
+			pTx:         spending(unspent),
+			expectedErr: ErrSnapshotMismatch,
+		},
+		{
+			name:     "unreachable and fresh",
+			snapshot: snapshot(func(*UTXOSnapshot) {}),
+			pAddr:    k.P(),
+			pTx:      spending(spent),
+			fetchErr: errors.New("unreachable"),
+		},
+		{
+			name:        "unreachable and old",
+			snapshot:    snapshot(old),
+			pAddr:       k.P(),
+			pTx:         spending(unspent),
+			fetchErr:    errors.New("unreachable"),
+			expectedErr: ErrStaleSnapshot,
+		},
+	}
+	for i, tv := range tt {
+		pc.cli = &utxosClient{utxos: currentUTXOs, err: tv.fetchErr}
+		err := pc.checkSnapshot(context.Background(), &Op{snapshot: tv.snapshot}, tv.pAddr, tv.pTx)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+	}
+}
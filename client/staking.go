@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/genesis"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"go.uber.org/zap"
+)
+
+// defaultMaxWeightFactor is the maximum ratio of the total validator
+// weight (stake plus delegations) to the validator's own stake.
+// It mirrors the P-Chain's "MaxValidatorWeightFactor", which is hardcoded
+// in the platformvm for the primary network and exposed neither by the
+// API nor by the genesis config.
+const defaultMaxWeightFactor = 5
+
+// StakingParams are the primary network staking parameters.
+type StakingParams struct {
+	// minimum primary network uptime, in [0, 1], to be rewarded
+	UptimeRequirement float64
+	// in nDJTX
+	MinValidatorStake uint64
+	MaxValidatorStake uint64
+	MinDelegatorStake uint64
+	// in "platformvm.PercentDenominator" units
+	MinDelegationFee uint32
+	MinStakeDuration time.Duration
+	MaxStakeDuration time.Duration
+	MaxWeightFactor  uint64
+	// Guessed is true if the parameters are the local network's rather
+	// than the network's own, as a custom network has no genesis config,
+	// and the node only reports the minimum stakes.
+	Guessed bool
+	// MinStakeFetched is true if the minimum stakes were reported by the
+	// node, and so are the network's even when "Guessed".
+	MinStakeFetched bool
+}
+
+// defaultStakingParams returns the genesis staking config of the network.
+// Unknown (custom) networks get the local network's config (e.g., its
+// minimum delegation fee), flagged as "Guessed".
+func defaultStakingParams(networkID uint32) StakingParams {
+	cfg := genesis.GetStakingConfig(networkID)
+	params := StakingParams{
+		UptimeRequirement: cfg.UptimeRequirement,
+		MinValidatorStake: cfg.MinValidatorStake,
+		MaxValidatorStake: cfg.MaxValidatorStake,
+		MinDelegatorStake: cfg.MinDelegatorStake,
+		MinDelegationFee:  cfg.MinDelegationFee,
+		MinStakeDuration:  cfg.MinStakeDuration,
+		MaxStakeDuration:  cfg.MaxStakeDuration,
+		MaxWeightFactor:   defaultMaxWeightFactor,
+	}
+	switch networkID {
+	case constants.MainnetID, constants.TahoeID, constants.LocalID:
+	default:
+		params.Guessed = true
+	}
+	return params
+}
+
+// getStakingParams returns the staking parameters, fetched on first use.
+// It starts from the genesis staking config of the network, and overrides
+// the minimum stakes with the ones reported by the node, since only those
+// are exposed by the P-Chain API. If the node fails to report them, it
+// falls back to the genesis config with a warning, and retries on the next
+// call.
+func (pc *p) getStakingParams(ctx context.Context) StakingParams {
+	pc.stakingMu.Lock()
+	defer pc.stakingMu.Unlock()
+	if pc.stakingParams != nil {
+		return *pc.stakingParams
+	}
+
+	params := defaultStakingParams(pc.networkID)
+	zap.L().Info("fetching staking parameters")
+	minValidatorStake, minDelegatorStake, err := pc.cli.GetMinStake(ctx, constants.PrimaryNetworkID)
+	if err != nil {
+		zap.L().Warn("failed to fetch staking parameters, falling back to the genesis config",
+			zap.String("networkName", pc.networkName),
+			zap.Error(err),
+		)
+		return params
+	}
+	params.MinValidatorStake = minValidatorStake
+	params.MinDelegatorStake = minDelegatorStake
+	params.MinStakeFetched = true
+	zap.L().Info("fetched staking parameters",
+		zap.Uint64("minValidatorStake", params.MinValidatorStake),
+		zap.Uint64("maxValidatorStake", params.MaxValidatorStake),
+		zap.Uint64("minDelegatorStake", params.MinDelegatorStake),
+		zap.Uint32("minDelegationFee", params.MinDelegationFee),
+		zap.Duration("minStakeDuration", params.MinStakeDuration),
+		zap.Duration("maxStakeDuration", params.MaxStakeDuration),
+		zap.Bool("guessed", params.Guessed),
+	)
+	pc.stakingParams = &params
+	return params
+}
+
+// checkStakingBound returns [err] for a violated staking bound if the
+// bound is the network's ([known]), and otherwise only warns, leaving it
+// to the P-Chain to reject the tx if the guessed bound was right.
+func (pc *p) checkStakingBound(ret *Op, known bool, err error) error {
+	if known {
+		return err
+	}
+	ret.warn(WarnStakingParamGuessed,
+		fmt.Sprintf("%v, but the bound is guessed for a custom network", err),
+		zap.String("networkName", pc.networkName),
+	)
+	return nil
+}
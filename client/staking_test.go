@@ -0,0 +1,226 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	api_info "github.com/lasthyphen/dijetsnodego/api/info"
+	"github.com/lasthyphen/dijetsnodego/genesis"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/rpc"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+)
+
+// minStakeClient reports [minStake] as the minimum validator and delegator
+// stakes, or fails with [err], and counts the calls.
+type minStakeClient struct {
+	platformvm.Client
+	minStake uint64
+	err      error
+	calls    int
+}
+
+func (c *minStakeClient) GetMinStake(context.Context, ids.ID, ...rpc.Option) (uint64, uint64, error) {
+	c.calls++
+	return c.minStake, c.minStake, c.err
+}
+
+// validatorsClient serves the [current] and [pending] primary network
+// validators, along with the minimum stakes.
+type validatorsClient struct {
+	minStakeClient
+	current []interface{}
+	pending []interface{}
+}
+
+func (c *validatorsClient) GetCurrentValidators(context.Context, ids.ID, []ids.ShortID, ...rpc.Option) ([]interface{}, error) {
+	return c.current, nil
+}
+
+func (c *validatorsClient) GetPendingValidators(context.Context, ids.ID, []ids.ShortID, ...rpc.Option) ([]interface{}, []interface{}, error) {
+	return c.pending, nil, nil
+}
+
+var errNoFee = errors.New("no fee")
+
+// noFeeInfo fails to report the tx fees, to stop an operation right
+// after its checks.
+type noFeeInfo struct {
+	api_info.Client
+}
+
+func (noFeeInfo) GetTxFee(context.Context, ...rpc.Option) (*api_info.GetTxFeeResponse, error) {
+	return nil, errNoFee
+}
+
+func TestDefaultStakingParams(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		networkID  uint32
+		expFee     uint32
+		expGuessed bool
+	}{
+		{networkID: constants.MainnetID, expFee: genesis.MainnetParams.MinDelegationFee},
+		{networkID: constants.TahoeID, expFee: genesis.TahoeParams.MinDelegationFee},
+		{networkID: constants.LocalID, expFee: genesis.LocalParams.MinDelegationFee},
+		{networkID: 1337, expFee: genesis.LocalParams.MinDelegationFee, expGuessed: true},
+	}
+	for i, tv := range tt {
+		params := defaultStakingParams(tv.networkID)
+		if params.MinDelegationFee != tv.expFee {
+			t.Fatalf("#%d(%d): unexpected min delegation fee %d, expected %d", i, tv.networkID, params.MinDelegationFee, tv.expFee)
+		}
+		if params.Guessed != tv.expGuessed {
+			t.Fatalf("#%d(%d): unexpected guessed %v, expected %v", i, tv.networkID, params.Guessed, tv.expGuessed)
+		}
+		if params.MaxWeightFactor != defaultMaxWeightFactor {
+			t.Fatalf("#%d(%d): unexpected max weight factor %d", i, tv.networkID, params.MaxWeightFactor)
+		}
+	}
+}
+
+func TestGetStakingParams(t *testing.T) {
+	t.Parallel()
+
+	// falls back to the genesis config, and retries on the next call
+	cli := &minStakeClient{minStake: 1, err: errors.New("unreachable")}
+	pc := &p{networkID: constants.LocalID, cli: cli}
+	params := pc.getStakingParams(context.Background())
+	if params.MinValidatorStake != genesis.LocalParams.MinValidatorStake || params.MinStakeFetched {
+		t.Fatalf("unexpected min validator stake %d, expected the genesis one", params.MinValidatorStake)
+	}
+
+	// fetched once, then cached
+	cli.err = nil
+	for i := 0; i < 2; i++ {
+		params = pc.getStakingParams(context.Background())
+		if params.MinValidatorStake != 1 || params.MinDelegatorStake != 1 || !params.MinStakeFetched {
+			t.Fatalf("#%d: unexpected min stakes %d/%d, expected the fetched ones", i, params.MinValidatorStake, params.MinDelegatorStake)
+		}
+	}
+	if cli.calls != 2 {
+		t.Fatalf("unexpected GetMinStake calls %d, expected 2", cli.calls)
+	}
+}
+
+// Only the staking bounds known for the network fail "AddValidator", and
+// the guessed ones of a custom network only warn.
+func TestAddValidatorStakingBounds(t *testing.T) {
+	t.Parallel()
+
+	k, _ := newTestStaker(t)
+	nodeID := ids.ShortID{1}
+	start := time.Now().Add(time.Hour)
+	local := genesis.LocalParams.StakingConfig
+
+	tt := []struct {
+		name        string
+		networkID   uint32
+		stakeAmt    uint64
+		period      time.Duration
+		fee         float64
+		expWarnings []WarningCode
+		expectedErr error
+	}{
+		{
+			name:        "in bounds",
+			networkID:   constants.LocalID,
+			stakeAmt:    local.MinValidatorStake,
+			period:      local.MinStakeDuration,
+			fee:         2,
+			expectedErr: errNoFee,
+		},
+		{
+			name:        "stake above max",
+			networkID:   constants.LocalID,
+			stakeAmt:    local.MaxValidatorStake + 1,
+			period:      local.MinStakeDuration,
+			fee:         2,
+			expectedErr: ErrInvalidStakeAmount,
+		},
+		{
+			name:        "stake above guessed max",
+			networkID:   1337,
+			stakeAmt:    local.MaxValidatorStake + 1,
+			period:      local.MinStakeDuration,
+			fee:         2,
+			expWarnings: []WarningCode{WarnStakingParamGuessed},
+			expectedErr: errNoFee,
+		},
+		{
+			name:        "stake below fetched min",
+			networkID:   1337,
+			stakeAmt:    local.MinValidatorStake - 1,
+			period:      local.MinStakeDuration,
+			fee:         2,
+			expectedErr: ErrInvalidStakeAmount,
+		},
+		{
+			name:        "period below min",
+			networkID:   constants.LocalID,
+			stakeAmt:    local.MinValidatorStake,
+			period:      local.MinStakeDuration - time.Hour,
+			fee:         2,
+			expectedErr: ErrInvalidValidatePeriod,
+		},
+		{
+			name:        "period below guessed min",
+			networkID:   1337,
+			stakeAmt:    local.MinValidatorStake,
+			period:      local.MinStakeDuration - time.Hour,
+			fee:         2,
+			expWarnings: []WarningCode{WarnStakingParamGuessed},
+			expectedErr: errNoFee,
+		},
+		{
+			name:        "fee below min",
+			networkID:   constants.LocalID,
+			stakeAmt:    local.MinValidatorStake,
+			period:      local.MinStakeDuration,
+			fee:         1,
+			expectedErr: ErrDelegationFeeTooLow,
+		},
+		{
+			name:        "fee below guessed min",
+			networkID:   1337,
+			stakeAmt:    local.MinValidatorStake,
+			period:      local.MinStakeDuration,
+			fee:         1,
+			expWarnings: []WarningCode{WarnStakingParamGuessed},
+			expectedErr: errNoFee,
+		},
+	}
+	for i, tv := range tt {
+		pc := &p{
+			networkID: tv.networkID,
+			cli:       &validatorsClient{minStakeClient: minStakeClient{minStake: local.MinValidatorStake}},
+			info:      noFeeInfo{},
+		}
+		var warnings []Warning
+		_, err := pc.AddValidator(context.Background(), k, nodeID, start, start.Add(tv.period),
+			WithStakeAmount(tv.stakeAmt),
+			WithDelegationFee(tv.fee),
+			WithRewardAddress(k.Address()),
+			WithChangeAddress(k.Address()),
+			WithWarnings(&warnings),
+		)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+		var codes []WarningCode
+		for _, w := range warnings {
+			codes = append(codes, w.Code)
+		}
+		if !reflect.DeepEqual(codes, tv.expWarnings) {
+			t.Fatalf("#%d(%s): unexpected warnings %v, expected %v", i, tv.name, codes, tv.expWarnings)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidSubnetConfig = errors.New("invalid subnet config")
+
+// SubnetConfig is the declarative state of a subnet, as exported by
+// "ExportSubnetConfig" (e.g., to keep in a GitOps repository) and
+// planned against by "PlanSubnetConfig". It only holds the public
+// on-chain state of the subnet, never keys.
+//
+// The IDs are kept as strings, since "ids.ID" only decodes from quoted
+// JSON strings.
+type SubnetConfig struct {
+	SubnetID string `yaml:"subnetId"`
+	// control keys of the subnet (e.g., "P-custom1...")
+	Threshold uint32   `yaml:"threshold"`
+	Owners    []string `yaml:"owners"`
+
+	Blockchains []SubnetConfigBlockchain `yaml:"blockchains"`
+
+	Validators []SubnetConfigValidator `yaml:"validators"`
+}
+
+type SubnetConfigBlockchain struct {
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
+	VMID string `yaml:"vmId"`
+	// hex-encoded SHA256 of the VM genesis bytes
+	GenesisHash string `yaml:"genesisHash"`
+}
+
+type SubnetConfigValidator struct {
+	// e.g., "NodeID-..."
+	NodeID string `yaml:"nodeId"`
+	Weight uint64 `yaml:"weight"`
+}
+
+// SubnetConfigPlan is the delta to converge the subnet validators to a
+// "SubnetConfig", as returned by "DiffValidators".
+type SubnetConfigPlan struct {
+	ToAdd         []ids.ShortID
+	ToRemove      []ids.ShortID
+	WeightChanges map[ids.ShortID]uint64
+}
+
+// Empty returns true if the subnet already matches the config.
+func (p *SubnetConfigPlan) Empty() bool {
+	return len(p.ToAdd) == 0 && len(p.ToRemove) == 0 && len(p.WeightChanges) == 0
+}
+
+func (pc *p) ExportSubnetConfig(ctx context.Context, subnetID ids.ID) ([]byte, error) {
+	if subnetID == ids.Empty {
+		return nil, ErrEmptyID
+	}
+	threshold, owners, err := pc.GetSubnetOwner(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &SubnetConfig{
+		SubnetID:    subnetID.String(),
+		Threshold:   threshold,
+		Owners:      owners,
+		Blockchains: make([]SubnetConfigBlockchain, 0),
+		Validators:  make([]SubnetConfigValidator, 0),
+	}
+
+	bcs, err := pc.cli.GetBlockchains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, bc := range bcs {
+		if bc.SubnetID != subnetID {
+			continue
+		}
+		genesisHash, err := pc.blockchainGenesisHash(ctx, bc.ID)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Blockchains = append(cfg.Blockchains, SubnetConfigBlockchain{
+			ID:          bc.ID.String(),
+			Name:        bc.Name,
+			VMID:        bc.VMID.String(),
+			GenesisHash: genesisHash,
+		})
+	}
+
+	vs, err := pc.GetSubnetValidators(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	for nodeID, weight := range vs {
+		cfg.Validators = append(cfg.Validators, SubnetConfigValidator{
+			NodeID: FormatNodeID(nodeID),
+			Weight: weight,
+		})
+	}
+	// stable output, so that exports can be diffed
+	sort.Slice(cfg.Validators, func(i, j int) bool { return cfg.Validators[i].NodeID < cfg.Validators[j].NodeID })
+
+	return yaml.Marshal(cfg)
+}
+
+// ParseSubnetConfig parses the YAML subnet config of "ExportSubnetConfig".
+func ParseSubnetConfig(b []byte) (*SubnetConfig, error) {
+	cfg := new(SubnetConfig)
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSubnetConfig, err)
+	}
+	if cfg.SubnetID == "" {
+		return nil, fmt.Errorf("%w: empty subnet ID", ErrInvalidSubnetConfig)
+	}
+	if _, err := ids.FromString(cfg.SubnetID); err != nil {
+		return nil, fmt.Errorf("%w: subnet ID %q (%v)", ErrInvalidSubnetConfig, cfg.SubnetID, err)
+	}
+	for _, bc := range cfg.Blockchains {
+		if _, err := ids.FromString(bc.ID); err != nil {
+			return nil, fmt.Errorf("%w: blockchain ID %q (%v)", ErrInvalidSubnetConfig, bc.ID, err)
+		}
+		if _, err := ids.FromString(bc.VMID); err != nil {
+			return nil, fmt.Errorf("%w: VM ID %q (%v)", ErrInvalidSubnetConfig, bc.VMID, err)
+		}
+	}
+	return cfg, nil
+}
+
+func (pc *p) PlanSubnetConfig(ctx context.Context, cfg *SubnetConfig) (*SubnetConfigPlan, error) {
+	subnetID, err := ids.FromString(cfg.SubnetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: subnet ID %q (%v)", ErrInvalidSubnetConfig, cfg.SubnetID, err)
+	}
+	desired := make(map[ids.ShortID]uint64, len(cfg.Validators))
+	for _, v := range cfg.Validators {
+		nodeID, err := ParseNodeID(v.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: validator %q (%v)", ErrInvalidSubnetConfig, v.NodeID, err)
+		}
+		if _, ok := desired[nodeID]; ok {
+			return nil, fmt.Errorf("%w: duplicate validator %q", ErrInvalidSubnetConfig, v.NodeID)
+		}
+		desired[nodeID] = v.Weight
+	}
+	toAdd, toRemove, weightChanges, err := pc.DiffValidators(ctx, subnetID, desired)
+	if err != nil {
+		return nil, err
+	}
+	return &SubnetConfigPlan{
+		ToAdd:         toAdd,
+		ToRemove:      toRemove,
+		WeightChanges: weightChanges,
+	}, nil
+}
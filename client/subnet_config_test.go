@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseSubnetConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := &SubnetConfig{
+		SubnetID:  ids.ID{1}.String(),
+		Threshold: 1,
+		Owners:    []string{"P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p"},
+		Blockchains: []SubnetConfigBlockchain{
+			{ID: ids.ID{2}.String(), Name: "spacesvm", VMID: ids.ID{3}.String(), GenesisHash: GenesisHash([]byte("{}"))},
+		},
+		Validators: []SubnetConfigValidator{
+			{NodeID: FormatNodeID(ids.ShortID{4}), Weight: 1000},
+		},
+	}
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseSubnetConfig(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, cfg) {
+		t.Fatalf("unexpected config %+v, expected %+v", parsed, cfg)
+	}
+
+	for i, s := range []string{
+		"threshold: 1\n",
+		"subnetId: invalid\n",
+		"subnetId: " + cfg.SubnetID + "\nblockchains:\n  - id: invalid\n",
+	} {
+		if _, err := ParseSubnetConfig([]byte(s)); !errors.Is(err, ErrInvalidSubnetConfig) {
+			t.Fatalf("#%d: unexpected error %v, expected %v", i, err, ErrInvalidSubnetConfig)
+		}
+	}
+}
+
+func TestPlanSubnetConfigInvalid(t *testing.T) {
+	t.Parallel()
+
+	nodeID := FormatNodeID(ids.ShortID{4})
+	tt := []struct {
+		name       string
+		validators []SubnetConfigValidator
+	}{
+		{
+			name:       "invalid node ID",
+			validators: []SubnetConfigValidator{{NodeID: "NodeID-invalid", Weight: 1000}},
+		},
+		{
+			name:       "duplicate validator",
+			validators: []SubnetConfigValidator{{NodeID: nodeID, Weight: 1000}, {NodeID: nodeID, Weight: 2000}},
+		},
+	}
+	for i, tv := range tt {
+		cfg := &SubnetConfig{SubnetID: ids.ID{1}.String(), Validators: tv.validators}
+		// fails before querying the node
+		_, err := (&p{}).PlanSubnetConfig(context.Background(), cfg)
+		if !errors.Is(err, ErrInvalidSubnetConfig) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, ErrInvalidSubnetConfig)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	api_info "github.com/lasthyphen/dijetsnodego/api/info"
+)
+
+// SupportBundle is the non-secret diagnostic information attached to bug
+// reports. It never carries keys, signatures, or URI credentials.
+type SupportBundle struct {
+	NetworkName string `json:"networkName"`
+	NetworkID   uint32 `json:"networkId"`
+	AssetID     string `json:"assetId"`
+	XChainID    string `json:"xChainId"`
+	PChainID    string `json:"pChainId"`
+
+	NodeVersion *api_info.GetNodeVersionReply `json:"nodeVersion,omitempty"`
+	Fees        *api_info.GetTxFeeResponse    `json:"fees,omitempty"`
+
+	Config SupportConfig `json:"config"`
+
+	// Errors records the node queries that failed, so that a partial
+	// bundle is still useful for triage.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// SupportConfig is the redacted form of [Config].
+type SupportConfig struct {
+	URI                string `json:"uri"`
+	PollInterval       string `json:"pollInterval"`
+	AssetID            string `json:"assetId,omitempty"`
+	PChainID           string `json:"pChainId,omitempty"`
+	ExpectedNetwork    string `json:"expectedNetwork,omitempty"`
+	StrictVersionCheck bool   `json:"strictVersionCheck"`
+	FaucetURL          string `json:"faucetUrl,omitempty"`
+}
+
+// SupportBundle collects diagnostic information about the client and the
+// node it is connected to, serialized as JSON. Node query failures are
+// recorded in the bundle rather than returned.
+func (cc *client) SupportBundle(ctx context.Context) ([]byte, error) {
+	b := SupportBundle{
+		NetworkName: cc.networkName,
+		NetworkID:   cc.networkID,
+		AssetID:     cc.assetID.String(),
+		XChainID:    cc.xChainID.String(),
+		PChainID:    cc.pChainID.String(),
+		Config: SupportConfig{
+			URI:                redactURI(cc.cfg.u),
+			PollInterval:       cc.cfg.PollInterval.String(),
+			ExpectedNetwork:    cc.cfg.ExpectedNetwork,
+			StrictVersionCheck: cc.cfg.StrictVersionCheck,
+		},
+	}
+	if cc.cfg.AssetID != nil {
+		b.Config.AssetID = cc.cfg.AssetID.String()
+	}
+	if cc.cfg.PChainID != nil {
+		b.Config.PChainID = cc.cfg.PChainID.String()
+	}
+	if cc.cfg.FaucetURL != "" {
+		// an unparsable URL can't be redacted, so only note it's set
+		b.Config.FaucetURL = "<invalid>"
+		if u, err := url.Parse(cc.cfg.FaucetURL); err == nil {
+			b.Config.FaucetURL = redactURI(u)
+		}
+	}
+
+	var err error
+	b.NodeVersion, err = cc.i.Client().GetNodeVersion(ctx)
+	if err != nil {
+		b.Errors = append(b.Errors, "node version: "+err.Error())
+	}
+	b.Fees, err = cc.i.Client().GetTxFee(ctx)
+	if err != nil {
+		b.Errors = append(b.Errors, "fees: "+err.Error())
+	}
+	return json.MarshalIndent(b, "", "  ")
+}
+
+// redactURI drops user info, query, and fragment, any of which may carry
+// API credentials.
+func redactURI(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/subnet-cli/internal/key"
+)
+
+// UnlockEvent is the total stakeable-locked amount
+// that becomes spendable at [Time].
+type UnlockEvent struct {
+	Time   time.Time
+	Amount uint64
+}
+
+func (pc *p) UnlockSchedule(ctx context.Context, k key.Key) ([]UnlockEvent, error) {
+	utxos, err := pc.getUTXOs(ctx, k, &Op{})
+	if err != nil {
+		return nil, err
+	}
+	return unlockSchedule(pc.assetID, utxos, uint64(time.Now().Unix())), nil
+}
+
+// unlockSchedule groups the amounts of the still-locked
+// "StakeableLockOut" UTXOs by locktime, sorted by time.
+// ref. "p.stake" for the same classification.
+func unlockSchedule(assetID ids.ID, utxos []*djtx.UTXO, now uint64) []UnlockEvent {
+	amounts := make(map[uint64]uint64)
+	for _, utxo := range utxos {
+		// assume "AssetID" is set to "DJTX" asset ID
+		if utxo.AssetID() != assetID {
+			continue
+		}
+		out, ok := utxo.Out.(*platformvm.StakeableLockOut)
+		if !ok {
+			// not locked, already spendable
+			continue
+		}
+		if out.Locktime <= now {
+			// no longer locked
+			continue
+		}
+		amounts[out.Locktime] += out.Amount()
+	}
+
+	events := make([]UnlockEvent, 0, len(amounts))
+	for locktime, amount := range amounts {
+		events = append(events, UnlockEvent{
+			Time:   time.Unix(int64(locktime), 0),
+			Amount: amount,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"go.uber.org/zap"
+)
+
+// ValidatorSetUpdate is the change of a validator set between two polls.
+type ValidatorSetUpdate struct {
+	Added         []ids.ShortID
+	Removed       []ids.ShortID
+	WeightChanged map[ids.ShortID]uint64
+	// Validators is the full validator set after the update.
+	Validators map[ids.ShortID]uint64
+}
+
+func (pc *p) SubscribeValidators(
+	ctx context.Context,
+	subnetID ids.ID,
+	interval time.Duration,
+) (<-chan ValidatorSetUpdate, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	// fetch the initial set synchronously to surface setup errors,
+	// and report it as all added
+	current, err := pc.GetSubnetValidators(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	toAdd, _, _ := diffValidators(map[ids.ShortID]uint64{}, current)
+
+	ch := make(chan ValidatorSetUpdate)
+	go func() {
+		defer close(ch)
+
+		update := &ValidatorSetUpdate{
+			Added:         toAdd,
+			Removed:       []ids.ShortID{},
+			WeightChanged: map[ids.ShortID]uint64{},
+			Validators:    current,
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if update != nil {
+				select {
+				case ch <- *update:
+				case <-ctx.Done():
+					return
+				}
+				update = nil
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+			desired, err := pc.GetSubnetValidators(ctx, subnetID)
+			if err != nil {
+				zap.L().Warn("failed to poll validators",
+					zap.String("subnetId", subnetID.String()),
+					zap.Error(err),
+				)
+				continue
+			}
+			toAdd, toRemove, weightChanges := diffValidators(current, desired)
+			current = desired
+			if len(toAdd) == 0 && len(toRemove) == 0 && len(weightChanges) == 0 {
+				continue
+			}
+			update = &ValidatorSetUpdate{
+				Added:         toAdd,
+				Removed:       toRemove,
+				WeightChanged: weightChanges,
+				Validators:    desired,
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// diffValidators computes the changes needed to converge the [current]
+// validator set to the [desired] one, where both map node IDs to weights.
+// Returned node IDs are sorted, so the result is deterministic.
+func diffValidators(current, desired map[ids.ShortID]uint64) (
+	toAdd []ids.ShortID,
+	toRemove []ids.ShortID,
+	weightChanges map[ids.ShortID]uint64,
+) {
+	toAdd = make([]ids.ShortID, 0)
+	toRemove = make([]ids.ShortID, 0)
+	weightChanges = make(map[ids.ShortID]uint64)
+	for nodeID, weight := range desired {
+		cur, ok := current[nodeID]
+		switch {
+		case !ok:
+			toAdd = append(toAdd, nodeID)
+		case cur != weight:
+			weightChanges[nodeID] = weight
+		}
+	}
+	for nodeID := range current {
+		if _, ok := desired[nodeID]; !ok {
+			toRemove = append(toRemove, nodeID)
+		}
+	}
+	sortShortIDs(toAdd)
+	sortShortIDs(toRemove)
+	return toAdd, toRemove, weightChanges
+}
+
+func sortShortIDs(s []ids.ShortID) {
+	sort.Slice(s, func(i, j int) bool {
+		return bytes.Compare(s[i][:], s[j][:]) < 0
+	})
+}
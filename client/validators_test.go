@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+)
+
+func TestDiffValidators(t *testing.T) {
+	t.Parallel()
+
+	n1, n2, n3, n4 := ids.ShortID{1}, ids.ShortID{2}, ids.ShortID{3}, ids.ShortID{4}
+	current := map[ids.ShortID]uint64{
+		n1: 1000,
+		n2: 1000,
+		n3: 1000,
+	}
+	desired := map[ids.ShortID]uint64{
+		n1: 1000,
+		n3: 2000,
+		n4: 1000,
+	}
+
+	toAdd, toRemove, weightChanges := diffValidators(current, desired)
+	if !reflect.DeepEqual(toAdd, []ids.ShortID{n4}) {
+		t.Fatalf("unexpected toAdd %v", toAdd)
+	}
+	if !reflect.DeepEqual(toRemove, []ids.ShortID{n2}) {
+		t.Fatalf("unexpected toRemove %v", toRemove)
+	}
+	if !reflect.DeepEqual(weightChanges, map[ids.ShortID]uint64{n3: 2000}) {
+		t.Fatalf("unexpected weightChanges %v", weightChanges)
+	}
+
+	toAdd, toRemove, weightChanges = diffValidators(current, current)
+	if len(toAdd) != 0 || len(toRemove) != 0 || len(weightChanges) != 0 {
+		t.Fatalf("unexpected diff %v %v %v, expected none", toAdd, toRemove, weightChanges)
+	}
+}
+
+func TestSubscribeValidatorsInvalidInterval(t *testing.T) {
+	t.Parallel()
+
+	// rejected before any request, so no client needed
+	pc := &p{}
+	for i, interval := range []time.Duration{0, -time.Second} {
+		if _, err := pc.SubscribeValidators(context.Background(), ids.ID{1}, interval); !errors.Is(err, ErrInvalidInterval) {
+			t.Fatalf("#%d(%v): unexpected error %v, expected %v", i, interval, err, ErrInvalidInterval)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lasthyphen/dijetsnodego/version"
+	"go.uber.org/zap"
+)
+
+var ErrIncompatibleNodeVersion = errors.New("incompatible node version")
+
+// The node versions whose tx formats this client builds.
+// Banff (v1.9.0) changes the P-Chain tx formats, so it's excluded.
+var (
+	minNodeVersion = &version.Semantic{Major: 1, Minor: 7, Patch: 0}
+	maxNodeVersion = &version.Semantic{Major: 1, Minor: 9, Patch: 0} // exclusive
+)
+
+// checkNodeVersion fetches the node version, which also checks that the
+// node is reachable, and checks it's within the supported range.
+// An incompatible version only warns, unless [strict] is set.
+func (cc *client) checkNodeVersion(ctx context.Context, strict bool) error {
+	zap.L().Info("fetching node version")
+	reply, err := cc.i.Client().GetNodeVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reach node %q: %w", redactURI(cc.cfg.u), err)
+	}
+	zap.L().Info("fetched node version", zap.String("version", reply.Version))
+
+	err = compatibleNodeVersion(reply.Version)
+	if err == nil {
+		return nil
+	}
+	if strict {
+		return err
+	}
+	zap.L().Warn("node version may be incompatible, txs may fail to be issued", zap.Error(err))
+	return nil
+}
+
+// compatibleNodeVersion checks the node application version [s]
+// (e.g., "dijets/1.7.14") is within the supported range.
+func compatibleNodeVersion(s string) error {
+	v, err := version.Parse("v" + s[strings.LastIndex(s, "/")+1:])
+	if err != nil {
+		return fmt.Errorf("%w: failed to parse %q (%v), expected >= %s and < %s",
+			ErrIncompatibleNodeVersion, s, err, minNodeVersion, maxNodeVersion)
+	}
+	if v.Compare(minNodeVersion) < 0 || v.Compare(maxNodeVersion) >= 0 {
+		return fmt.Errorf("%w: detected %s, expected >= %s and < %s",
+			ErrIncompatibleNodeVersion, v, minNodeVersion, maxNodeVersion)
+	}
+	return nil
+}
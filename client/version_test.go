@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompatibleNodeVersion(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		version string
+		expErr  error
+	}{
+		{version: "dijets/1.7.0", expErr: nil},
+		{version: "dijets/1.8.14", expErr: nil},
+		{version: "dijets/1.6.5", expErr: ErrIncompatibleNodeVersion},
+		{version: "dijets/1.9.0", expErr: ErrIncompatibleNodeVersion},
+		{version: "dijets", expErr: ErrIncompatibleNodeVersion},
+	}
+	for i, tv := range tt {
+		err := compatibleNodeVersion(tv.version)
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.version, err, tv.expErr)
+		}
+	}
+}
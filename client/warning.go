@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"go.uber.org/zap"
+)
+
+type WarningCode string
+
+const (
+	WarnStakeAmountDefaulted   WarningCode = "stake-amount-defaulted"
+	WarnRewardSharesDefaulted  WarningCode = "reward-shares-defaulted"
+	WarnRewardAddressDefaulted WarningCode = "reward-address-defaulted"
+	WarnChangeAddressDefaulted WarningCode = "change-address-defaulted"
+	WarnAddressHRPMismatch     WarningCode = "address-hrp-mismatch"
+	WarnLowUptime              WarningCode = "low-uptime"
+	WarnStakingParamGuessed    WarningCode = "staking-param-guessed"
+	WarnValidatorAlreadyAdded  WarningCode = "validator-already-added"
+)
+
+// Warning is a decision the client made on behalf of the caller
+// (e.g., defaulting where the funds go) that didn't fail the operation.
+type Warning struct {
+	Code    WarningCode
+	Message string
+}
+
+// To collect the warnings of the operation into [w] (e.g., to show them
+// in a GUI), in addition to logging them.
+func WithWarnings(w *[]Warning) OpOption {
+	return func(op *Op) {
+		op.warnings = w
+	}
+}
+
+// warn logs the warning, and records it if "WithWarnings" is set.
+func (op *Op) warn(code WarningCode, msg string, fields ...zap.Field) {
+	zap.L().Warn(msg, append(fields, zap.String("code", string(code)))...)
+	if op.warnings != nil {
+		*op.warnings = append(*op.warnings, Warning{Code: code, Message: msg})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/formatting"
+)
+
+func TestWithWarnings(t *testing.T) {
+	t.Parallel()
+
+	k, pc := newTestStaker(t)
+	pc.cli = &minStakeClient{minStake: 1}
+	localAddr, err := formatting.FormatAddress("P", constants.GetHRP(pc.networkID), k.Address().Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreignAddr, err := formatting.FormatAddress("P", constants.FallbackHRP, k.Address().Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		name     string
+		warn     func(ret *Op)
+		expected []WarningCode
+	}{
+		{
+			name:     "low uptime",
+			warn:     func(ret *Op) { pc.checkUptime(context.Background(), ret, k.Address(), 0.5) },
+			expected: []WarningCode{WarnLowUptime},
+		},
+		{
+			name: "sufficient uptime",
+			warn: func(ret *Op) { pc.checkUptime(context.Background(), ret, k.Address(), 0.9) },
+		},
+		{
+			name: "HRP mismatch",
+			warn: func(ret *Op) {
+				if _, err := pc.parsePAddress(ret, foreignAddr); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expected: []WarningCode{WarnAddressHRPMismatch},
+		},
+		{
+			name: "HRP match",
+			warn: func(ret *Op) {
+				if _, err := pc.parsePAddress(ret, localAddr); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "in order",
+			warn: func(ret *Op) {
+				pc.checkUptime(context.Background(), ret, k.Address(), 0.5)
+				if _, err := pc.parsePAddress(ret, foreignAddr); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expected: []WarningCode{WarnLowUptime, WarnAddressHRPMismatch},
+		},
+	}
+	for i, tv := range tt {
+		var warnings []Warning
+		ret := &Op{}
+		ret.applyOpts([]OpOption{WithWarnings(&warnings), WithMinUptime(0.8)})
+		tv.warn(ret)
+
+		var codes []WarningCode
+		for _, w := range warnings {
+			if w.Message == "" {
+				t.Fatalf("#%d(%s): empty message for %q", i, tv.name, w.Code)
+			}
+			codes = append(codes, w.Code)
+		}
+		if !reflect.DeepEqual(codes, tv.expected) {
+			t.Fatalf("#%d(%s): unexpected warnings %v, expected %v", i, tv.name, codes, tv.expected)
+		}
+
+		// only logged without "WithWarnings"
+		tv.warn(&Op{minUptime: 0.8, minUptimeSet: true})
+	}
+}
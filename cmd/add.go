@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/subnet-cli/client"
 	"github.com/dustin/go-humanize"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/spf13/cobra"
@@ -25,6 +26,7 @@ func AddCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://dijets.ukwest.cloudapp.azure.com:443/", "URI for avalanche network endpoints")
 	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
 	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().IntVar(&ledgerAccountIndex, "ledger-account-index", -1, "ledger account index to use without prompting (default to prompt)")
 	return cmd
 }
 
@@ -34,11 +36,28 @@ func CreateAddTable(i *Info) string {
 	if i.subnetID != ids.Empty {
 		tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.subnetID)})
 	}
+	for _, nodeID := range i.nodeIDs {
+		uptime, ok := i.uptimes[nodeID]
+		if !ok {
+			continue
+		}
+		uptimes := formatter.F("{{light-gray}}{{bold}}%s: %.2f%%{{/}}", nodeID, uptime*100)
+		if uptime < i.minUptime {
+			uptimes = formatter.F("{{red}}{{bold}}%s: %.2f%% (below %.2f%%){{/}}", nodeID, uptime*100, i.minUptime*100)
+		}
+		tb.Append([]string{formatter.F("{{orange}}NODE UPTIME{{/}}"), uptimes})
+	}
 	if !i.validateStart.IsZero() {
 		tb.Append([]string{formatter.F("{{magenta}}VALIDATE START{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.validateStart.Format(time.RFC3339))})
 	}
 	if !i.validateEnd.IsZero() {
 		tb.Append([]string{formatter.F("{{magenta}}VALIDATE END{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.validateEnd.Format(time.RFC3339))})
+		// start is only set once the validator is being added
+		start := i.validateStart
+		if start.IsZero() {
+			start = time.Now()
+		}
+		tb.Append([]string{formatter.F("{{magenta}}VALIDATE DURATION{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", client.FormatStakingDuration(i.validateEnd.Sub(start)))})
 	}
 	if i.validateWeight > 0 {
 		tb.Append([]string{formatter.F("{{magenta}}VALIDATE WEIGHT{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", humanize.Comma(int64(i.validateWeight)))})
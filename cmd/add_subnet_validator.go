@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/subnet-cli/client"
 	"github.com/lasthyphen/subnet-cli/pkg/color"
 	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
@@ -41,23 +42,44 @@ $ subnet-cli add subnet-validator \
 
 	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
 	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringSliceVar(&nodeCertPaths, "node-cert-paths", nil, "a list of node staking certificate (staker.crt) paths to derive node IDs from")
 	cmd.PersistentFlags().Uint64Var(&validateWeight, "validate-weight", defaultValidateWeight, "validate weight")
+	cmd.PersistentFlags().Float64Var(&minUptimePercent, "min-uptime", 0, "warn if the primary network uptime percentage of a node is below this (default to the network uptime requirement)")
+	cmd.PersistentFlags().BoolVar(&requireConnected, "require-connected", false, "fail if the primary network validator of a node is not connected")
 
 	return cmd
 }
 
-var errZeroValidateWeight = errors.New("zero validate weight")
+var (
+	errZeroValidateWeight = errors.New("zero validate weight")
+	errElasticSubnet      = errors.New("elastic subnet")
+)
 
 func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
 	}
+	defer info.Close()
 	info.subnetID, err = ids.FromString(subnetIDs)
 	if err != nil {
 		return err
 	}
-	info.txFee = uint64(info.feeData.TxFee)
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+	subnetType, err := cli.P().GetSubnetType(ctx, info.subnetID)
+	cancel()
+	switch {
+	case errors.Is(err, client.ErrUnknownSubnetType):
+		// e.g., a node without "getStakingAssetID", which predates elastic
+		// subnets, so only warn and let the node reject the tx if elastic
+		color.Outf("{{yellow}}could not tell whether subnet %s is elastic, adding as permissioned (%v){{/}}\n", info.subnetID, err)
+	case err != nil:
+		return err
+	case subnetType == client.SubnetTypeElastic:
+		// the owners can no longer add validators once transformed
+		return fmt.Errorf("%w: %s must be validated permissionlessly by staking its asset", errElasticSubnet, info.subnetID)
+	}
+	info.txFee = client.Fee(info.feeData, client.OperationAddSubnetValidator)
 	if err := ParseNodeIDs(cli, info); err != nil {
 		return err
 	}
@@ -75,9 +97,39 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 	info.rewardAddr = ids.ShortEmpty
 	info.changeAddr = ids.ShortEmpty
 
-	info.txFee *= uint64(len(info.nodeIDs))
-	info.requiredBalance = info.txFee
-	if err := info.CheckBalance(); err != nil {
+	opts := []client.OpOption{client.WithMatchPrimaryWindow(), client.WithRequireConnected(requireConnected), client.WithAuditLog(auditLogPath)}
+	ctx, cancel = context.WithTimeout(cmd.Context(), requestTimeout)
+	info.minUptime = cli.StakingParams(ctx).UptimeRequirement
+	cancel()
+	if minUptimePercent > 0 {
+		info.minUptime = minUptimePercent / 100
+		opts = append(opts, client.WithMinUptime(info.minUptime))
+	}
+	// fetched once for the uptimes shown, and reused to add the validators
+	primaries := make(map[ids.ShortID]*client.ValidatorDetails, len(info.nodeIDs))
+	info.uptimes = make(map[ids.ShortID]float64, len(info.nodeIDs))
+	for _, nodeID := range info.nodeIDs {
+		ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+		primary, err := cli.P().GetValidatorDetails(ctx, ids.Empty, nodeID)
+		cancel()
+		if err != nil {
+			// only advisory, so don't block adding the validator
+			color.Outf("{{yellow}}failed to get uptime of %s (%v){{/}}\n", nodeID, err)
+			continue
+		}
+		primaries[nodeID] = primary
+		info.uptimes[nodeID] = primary.Uptime
+	}
+
+	info.requiredBalance, err = client.RequiredBalanceN(client.OperationAddSubnetValidator, info.txFee, 0, len(info.nodeIDs))
+	if err != nil {
+		return err
+	}
+	info.txFee, err = client.RequiredBalanceN(client.OperationAddSubnetValidator, info.txFee, 0, len(info.nodeIDs))
+	if err != nil {
+		return err
+	}
+	if err := info.CheckBalance(cli); err != nil {
 		return err
 	}
 	msg := CreateAddTable(info)
@@ -108,24 +160,18 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 	println()
 	println()
 	for _, nodeID := range info.nodeIDs {
-		// valInfo is not populated because [ParseNodeIDs] called on info.subnetID
-		//
-		// TODO: cleanup
-		_, end, err := cli.P().GetValidator(context.Background(), ids.Empty, nodeID)
-		if err != nil {
-			return err
-		}
-		info.validateStart = time.Now().Add(30 * time.Second)
-		info.validateEnd = end
-		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		// valInfo is not populated because [ParseNodeIDs] called on info.subnetID,
+		// so validate for the primary network validation period of the node
+		ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
 		took, err := cli.P().AddSubnetValidator(
 			ctx,
 			info.key,
 			info.subnetID,
 			nodeID,
-			info.validateStart,
-			info.validateEnd,
+			time.Time{},
+			time.Time{},
 			validateWeight,
+			append([]client.OpOption{client.WithPrimaryValidator(primaries[nodeID])}, opts...)...,
 		)
 		cancel()
 		if err != nil {
@@ -137,8 +183,8 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.txFee = 0
-	info.balance, err = cli.P().Balance(context.Background(), info.key)
-	if err != nil {
+	info.uptimes = nil
+	if err := info.RefreshBalances(cli); err != nil {
 		return err
 	}
 	fmt.Fprint(formatter.ColorableStdOut, CreateAddTable(info))
@@ -11,9 +11,8 @@ import (
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
-	"github.com/lasthyphen/dijetsnodego/utils/constants"
-	"github.com/lasthyphen/dijetsnodego/utils/units"
 	"github.com/lasthyphen/subnet-cli/client"
+	"github.com/lasthyphen/subnet-cli/pkg/amount"
 	"github.com/lasthyphen/subnet-cli/pkg/color"
 	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
@@ -21,7 +20,6 @@ import (
 )
 
 const (
-	defaultStakeAmount   = 1 * units.Djtx
 	defaultValFeePercent = 2
 	defaultStagger       = 2 * time.Hour
 	defaultValDuration   = 300 * 24 * time.Hour
@@ -38,7 +36,7 @@ $ subnet-cli add validator \
 --private-key-path=.insecure.ewoq.key \
 --public-uri=http://localhost:52250 \
 --node-ids="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
---stake-amount=2000000000000 \
+--stake-amount=2000 \
 --validate-reward-fee-percent=2
 
 `,
@@ -46,13 +44,14 @@ $ subnet-cli add validator \
 	}
 
 	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
-	cmd.PersistentFlags().Uint64Var(&stakeAmount, "stake-amount", defaultStakeAmount, "stake amount denominated in nano DJTX (minimum amount that a validator must stake is 2,000 DJTX)")
+	cmd.PersistentFlags().StringSliceVar(&nodeCertPaths, "node-cert-paths", nil, "a list of node staking certificate (staker.crt) paths to derive node IDs from")
+	cmd.PersistentFlags().StringVar(&stakeAmounts, "stake-amount", "", "stake amount denominated in DJTX (e.g., 2000.5) (default to the minimum validator stake of the network)")
 
 	end := time.Now().Add(defaultValDuration)
 	cmd.PersistentFlags().StringVar(&validateEnds, "validate-end", end.Format(time.RFC3339), "validate start timestamp in RFC3339 format")
 	cmd.PersistentFlags().Uint32Var(&validateRewardFeePercent, "validate-reward-fee-percent", defaultValFeePercent, "percentage of fee that the validator will take rewards from its delegators")
-	cmd.PersistentFlags().StringVar(&rewardAddrs, "reward-address", "", "node address to send rewards to (default to key owner)")
-	cmd.PersistentFlags().StringVar(&changeAddrs, "change-address", "", "node address to send changes to (default to key owner)")
+	cmd.PersistentFlags().StringVar(&rewardAddrs, "reward-address", "", "P-Chain address to send rewards to (default to key owner)")
+	cmd.PersistentFlags().StringVar(&changeAddrs, "change-address", "", "P-Chain address to send changes to (default to key owner)")
 
 	return cmd
 }
@@ -64,7 +63,16 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	info.stakeAmount = stakeAmount
+	defer info.Close()
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+	info.stakeAmount = cli.StakingParams(ctx).MinValidatorStake
+	cancel()
+	if stakeAmounts != "" {
+		info.stakeAmount, err = amount.ParseDJTX(stakeAmounts)
+		if err != nil {
+			return err
+		}
+	}
 
 	info.subnetID = ids.Empty
 	if err := ParseNodeIDs(cli, info); err != nil {
@@ -86,7 +94,7 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 	}
 
 	if rewardAddrs != "" {
-		info.rewardAddr, err = ids.ShortFromPrefixedString(rewardAddrs, constants.NodeIDPrefix)
+		info.rewardAddr, err = client.ParsePAddress(rewardAddrs)
 		if err != nil {
 			return err
 		}
@@ -94,15 +102,24 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 		info.rewardAddr = info.key.Address()
 	}
 	if changeAddrs != "" {
-		info.changeAddr, err = ids.ShortFromPrefixedString(changeAddrs, constants.NodeIDPrefix)
+		info.changeAddr, err = client.ParsePAddress(changeAddrs)
 		if err != nil {
 			return err
 		}
 	} else {
 		info.changeAddr = info.key.Address()
 	}
-	info.requiredBalance = info.stakeAmount * uint64(len(info.nodeIDs))
-	if err := info.CheckBalance(); err != nil {
+	txFee := client.Fee(info.feeData, client.OperationAddValidator)
+	info.requiredBalance, err = client.RequiredBalanceN(client.OperationAddValidator, txFee, info.stakeAmount, len(info.nodeIDs))
+	if err != nil {
+		return err
+	}
+	// the fee alone, without the stake
+	info.txFee, err = client.RequiredBalanceN(client.OperationAddValidator, txFee, 0, len(info.nodeIDs))
+	if err != nil {
+		return err
+	}
+	if err := info.CheckBalance(cli); err != nil {
 		return err
 	}
 	msg := CreateAddTable(info)
@@ -133,7 +150,7 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 	println()
 	println()
 	for i, nodeID := range info.nodeIDs {
-		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
 		info.validateStart = time.Now().Add(30 * time.Second)
 		took, err := cli.P().AddValidator(
 			ctx,
@@ -145,6 +162,7 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 			client.WithRewardShares(info.validateRewardFeePercent*10000),
 			client.WithRewardAddress(info.rewardAddr),
 			client.WithChangeAddress(info.changeAddr),
+			client.WithAuditLog(auditLogPath),
 		)
 		cancel()
 		if err != nil {
@@ -159,8 +177,7 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.txFee = 0
-	info.balance, err = cli.P().Balance(context.Background(), info.key)
-	if err != nil {
+	if err := info.RefreshBalances(cli); err != nil {
 		return err
 	}
 	fmt.Fprint(formatter.ColorableStdOut, CreateAddTable(info))
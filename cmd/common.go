@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/lasthyphen/dijetsnodego/api/info"
@@ -16,13 +17,14 @@ import (
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
 	"github.com/lasthyphen/dijetsnodego/utils/units"
 	"github.com/dustin/go-humanize"
-	"github.com/manifoldco/promptui"
 	"github.com/olekukonko/tablewriter"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"go.uber.org/zap"
 
 	"github.com/lasthyphen/subnet-cli/client"
 	"github.com/lasthyphen/subnet-cli/internal/key"
+	internal_platformvm "github.com/lasthyphen/subnet-cli/internal/platformvm"
+	"github.com/lasthyphen/subnet-cli/pkg/amount"
 	"github.com/lasthyphen/subnet-cli/pkg/color"
 	"github.com/lasthyphen/subnet-cli/pkg/logutil"
 )
@@ -30,24 +32,33 @@ import (
 type ValInfo struct {
 	start time.Time
 	end   time.Time
+
+	// true if added but not started validating yet
+	pending bool
 }
 
 type Info struct {
 	uri string
 
-	feeData *info.GetTxFeeResponse
-	balance uint64
+	feeData          *info.GetTxFeeResponse
+	zeroFees         []string // fees unexpectedly zero on the network
+	balance          uint64
+	spendableBalance uint64
 
 	txFee           uint64
 	stakeAmount     uint64
 	requiredBalance uint64
+	// warn once the balance after the operation falls below this
+	lowBalanceThreshold uint64
 
 	key key.Key
 
 	networkName string
 
-	subnetIDType string
-	subnetID     ids.ID
+	subnetIDType       string
+	subnetID           ids.ID
+	subnetThreshold    uint32
+	subnetControlAddrs []string
 
 	nodeIDs    []ids.ShortID
 	allNodeIDs []ids.ShortID
@@ -57,21 +68,44 @@ type Info struct {
 	chainName     string
 	vmID          ids.ID
 	vmGenesisPath string
+	vmGenesisHash string
 
 	validateStart            time.Time
 	validateEnd              time.Time
 	validateWeight           uint64
 	validateRewardFeePercent uint32
 
+	// primary network uptimes of the nodes, in [0, 1]
+	uptimes   map[ids.ShortID]float64
+	minUptime float64
+
 	rewardAddr ids.ShortID
 	changeAddr ids.ShortID
 }
 
 func InitClient(uri string, loadKey bool) (client.Client, *Info, error) {
-	cli, err := client.New(client.Config{
-		URI:          uri,
-		PollInterval: pollInterval,
-	})
+	cfg := client.Config{
+		URI:                uri,
+		PollInterval:       pollInterval,
+		ExpectedNetwork:    expectedNetwork,
+		StrictVersionCheck: strictVersion,
+		FaucetURL:          faucetURL,
+	}
+	if assetIDs != "" {
+		assetID, err := ids.FromString(assetIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.AssetID = &assetID
+	}
+	if pChainIDs != "" {
+		pChainID, err := ids.FromString(pChainIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.PChainID = &pChainID
+	}
+	cli, err := client.New(cfg)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -89,11 +123,33 @@ func InitClient(uri string, loadKey bool) (client.Client, *Info, error) {
 		networkName: networkName,
 		valInfos:    map[ids.ShortID]*ValInfo{},
 	}
+	info.zeroFees = client.ZeroFees(cli.NetworkID(), txFee)
+	if len(info.zeroFees) > 0 {
+		zap.L().Warn("node reports zero fees, which the network should charge",
+			zap.String("networkName", networkName),
+			zap.Strings("fees", info.zeroFees),
+		)
+	}
+	// default to 3x the tx fee
+	info.lowBalanceThreshold = 3 * uint64(txFee.TxFee)
+	if lowBalanceWarning != "" {
+		info.lowBalanceThreshold, err = amount.ParseDJTX(lowBalanceWarning)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	if !loadKey {
 		return cli, info, nil
 	}
 
 	if !useLedger {
+		keyNetwork, err := readKeyNetwork(privKeyPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := checkKeyNetwork(keyNetwork, cli.NetworkID()); err != nil {
+			return nil, nil, err
+		}
 		info.key, err = key.LoadSoft(cli.NetworkID(), privKeyPath)
 		if err != nil {
 			return nil, nil, err
@@ -102,43 +158,157 @@ func InitClient(uri string, loadKey bool) (client.Client, *Info, error) {
 		if err != nil {
 			return nil, nil, err
 		}
+		info.spendableBalance, err = cli.P().SpendableBalance(context.TODO(), info.key, time.Now())
+		if err != nil {
+			return nil, nil, err
+		}
+		checkImportable(cli, info.key)
 		return cli, info, nil
 	}
 
+	sel := selector
+	if ledgerAccountIndex >= 0 {
+		sel = ledgerIndexSelector(uint32(ledgerAccountIndex))
+	}
+	hk, balance, err := selectLedgerKey(
+		sel,
+		func(i uint32) (key.Key, error) {
+			return key.NewHard(cli.NetworkID(), i)
+		},
+		func(k key.Key) (uint64, error) {
+			return cli.P().Balance(context.TODO(), k)
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	info.key = hk
+	info.balance = balance
+	info.spendableBalance, err = cli.P().SpendableBalance(context.TODO(), hk, time.Now())
+	if err != nil {
+		_ = hk.Close()
+		return nil, nil, err
+	}
+	checkImportable(cli, hk)
+	return cli, info, nil
+}
+
+// checkImportable tells the user about the funds exported from the X-Chain
+// to [k] that must be imported first, since they don't show up in the
+// P-Chain balance.
+func checkImportable(cli client.Client, k key.Key) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	importable, err := cli.P().ImportableBalance(ctx, k)
+	cancel()
+	if err != nil {
+		// only advisory, so don't block the operation
+		zap.L().Debug("failed to check importable X-Chain funds", zap.Error(err))
+		return
+	}
+	if importable > 0 {
+		color.Outf("{{yellow}}you have %s $DJTX on the X-Chain pending import to %s, import it to the P-Chain to spend it{{/}}\n", amount.FormatDJTX(importable), k.P())
+	}
+}
+
+// keyNetworkSuffix names the file next to a key file with the network
+// the key was created for (e.g., "tahoe"), as written by "create key".
+// The key file itself only holds the private key, whose addresses are
+// formatted with the HRP of whichever network the node is on.
+const keyNetworkSuffix = ".network"
+
+// readKeyNetwork returns the network stored next to the key file at
+// [keyPath], or empty if the key was not created for a given network.
+func readKeyNetwork(keyPath string) (string, error) {
+	b, err := os.ReadFile(keyPath + keyNetworkSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// checkKeyNetwork fails with "ErrNetworkKeyMismatch" if the address HRP of
+// the network [keyNetwork] the key was created for is not the one of the
+// node's [networkID], since querying balances for a foreign-network
+// address would only show up as insufficient funds. Keys not created
+// for a given network (e.g., Ledger keys) are not checked.
+func checkKeyNetwork(keyNetwork string, networkID uint32) error {
+	if keyNetwork == "" {
+		return nil
+	}
+	keyNetworkID, err := constants.NetworkID(keyNetwork)
+	if err != nil {
+		return fmt.Errorf("%w: invalid key network %q (%v)", ErrNetworkKeyMismatch, keyNetwork, err)
+	}
+	hrp, expected := constants.GetHRP(keyNetworkID), constants.GetHRP(networkID)
+	if hrp != expected {
+		return fmt.Errorf("%w: key is for %s (HRP %q), but network %d expects %q",
+			ErrNetworkKeyMismatch, keyNetwork, hrp, networkID, expected)
+	}
+	return nil
+}
+
+// selectLedgerKey iterates the Ledger account indexes, and prompts via [sel]
+// to continue with the current address (item 0) or try the next (item 1).
+// Keys that are not selected are closed.
+func selectLedgerKey(
+	sel Selector,
+	newKey func(i uint32) (key.Key, error),
+	balanceOf func(k key.Key) (uint64, error),
+) (key.Key, uint64, error) {
 	for i := uint32(0); ; i++ {
-		hk, err := key.NewHard(cli.NetworkID(), i)
+		hk, err := newKey(i)
 		if err != nil {
-			return nil, nil, err
+			return nil, 0, err
 		}
-		balance, err := cli.P().Balance(context.TODO(), hk)
+		balance, err := balanceOf(hk)
 		if err != nil {
-			return nil, nil, err
+			_ = hk.Close()
+			return nil, 0, err
 		}
 		curPChainDenominatedP := float64(balance) / float64(units.Djtx)
 		curPChainDenominatedBalanceP := humanize.FormatFloat("#,###.#######", curPChainDenominatedP)
-		prompt := promptui.Select{
-			Label:  "\n",
-			Stdout: os.Stdout,
-			Items: []string{
-				formatter.F("{{green}}Continue with %s (%s DJTX){{/}}", hk.P(), curPChainDenominatedBalanceP),
-				formatter.F("{{red}}Try next address (idx=%d){{/}}", i+1),
-			},
-		}
-		idx, _, err := prompt.Run()
+		idx, err := sel.Select("\n", []string{
+			formatter.F("{{green}}Continue with %s (%s DJTX){{/}}", hk.P(), curPChainDenominatedBalanceP),
+			formatter.F("{{red}}Try next address (idx=%d){{/}}", i+1),
+		})
 		if err != nil {
-			return nil, nil, err
+			_ = hk.Close()
+			return nil, 0, err
 		}
 		if idx == 0 {
-			info.key = hk
-			info.balance = balance
-			return cli, info, nil
+			return hk, balance, nil
 		}
-		if err := hk.Disconnect(); err != nil {
-			return nil, nil, err
+		if err := hk.Close(); err != nil {
+			return nil, 0, err
 		}
 	}
 }
 
+// RefreshBalances reloads the total and spendable balances of the key,
+// e.g., after issuing txs.
+func (i *Info) RefreshBalances(cli client.Client) (err error) {
+	i.balance, err = cli.P().Balance(context.Background(), i.key)
+	if err != nil {
+		return err
+	}
+	i.spendableBalance, err = cli.P().SpendableBalance(context.Background(), i.key, time.Now())
+	return err
+}
+
+// Close releases the loaded key (e.g., disconnects the Ledger),
+// and must be deferred once "InitClient" succeeds.
+func (i *Info) Close() {
+	if i.key == nil {
+		return
+	}
+	if err := i.key.Close(); err != nil {
+		zap.L().Warn("failed to close key", zap.Error(err))
+	}
+}
+
 func CreateLogger() error {
 	lcfg := logutil.GetDefaultZapLoggerConfig()
 	lcfg.Level = zap.NewAtomicLevelAt(logutil.ConvertToZapLevel(logLevel))
@@ -150,14 +320,36 @@ func CreateLogger() error {
 	return nil
 }
 
-func (i *Info) CheckBalance() error {
+// CheckBalance fails if the balance doesn't cover the operation, with a
+// faucet hint on test networks.
+func (i *Info) CheckBalance(cli client.Client) error {
 	if i.balance < i.requiredBalance {
-		color.Outf("{{red}}insufficient funds to perform operation. get more at https://faucet.avax-test.network{{/}}\n")
+		color.Outf("{{red}}insufficient funds to perform operation{{/}}\n")
+		if cli.NetworkID() != constants.MainnetID {
+			faucet := faucetURL
+			if faucet == "" {
+				faucet = client.DefaultFaucetURL
+			}
+			color.Outf("{{red}}get more at %s (or with 'subnet-cli faucet'){{/}}\n", faucet)
+		}
 		return fmt.Errorf("%w: on %s (expected=%d, have=%d)", ErrInsufficientFunds, i.key.P(), i.requiredBalance, i.balance)
 	}
+	if i.spendableBalance < i.requiredBalance {
+		// stakeable-locked outputs can still be staked, so only warn
+		color.Outf("{{yellow}}only %s of the %s $DJTX on %s are spendable now, the operation may fail{{/}}\n", amount.FormatDJTX(i.spendableBalance), amount.FormatDJTX(i.balance), i.key.P())
+	}
+	if i.lowBalance() {
+		color.Outf("{{yellow}}balance running low on %s, top up before the next operations{{/}}\n", i.key.P())
+	}
 	return nil
 }
 
+// lowBalance returns true if the balance left after
+// the operation is below the low balance threshold.
+func (i *Info) lowBalance() bool {
+	return i.balance < i.requiredBalance+i.lowBalanceThreshold
+}
+
 func BaseTableSetup(i *Info) (*bytes.Buffer, *tablewriter.Table) {
 	// P-Chain balance is denominated by units.Djtx or 10^9 nano-Djtx
 	curPChainDenominatedP := float64(i.balance) / float64(units.Djtx)
@@ -175,6 +367,22 @@ func BaseTableSetup(i *Info) (*bytes.Buffer, *tablewriter.Table) {
 
 	tb.Append([]string{formatter.F("{{cyan}}{{bold}}P-CHAIN ADDRESS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.key.P())})
 	tb.Append([]string{formatter.F("{{coral}}{{bold}}P-CHAIN BALANCE{{/}} "), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $DJTX", curPChainDenominatedBalanceP)})
+	if i.key != nil && i.lowBalance() {
+		tb.Append([]string{formatter.F("{{yellow}}{{bold}}LOW BALANCE WARNING{{/}}"), formatter.F("{{yellow}}below %s $DJTX after this operation{{/}}", amount.FormatDJTX(i.lowBalanceThreshold))})
+	}
+	if i.key != nil {
+		spendable := float64(i.spendableBalance) / float64(units.Djtx)
+		spendables := humanize.FormatFloat("#,###.#######", spendable)
+		tb.Append([]string{formatter.F("{{coral}}{{bold}}P-CHAIN SPENDABLE BALANCE{{/}} "), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $DJTX", spendables)})
+		if i.balance > i.spendableBalance {
+			// the balance also counts the outputs that are still locked,
+			// or that this key alone can't sign for (e.g., multisig)
+			tb.Append([]string{formatter.F("{{yellow}}{{bold}}NOT SPENDABLE NOW{{/}}"), formatter.F("{{yellow}}%s $DJTX locked or not spendable by this key alone{{/}}", amount.FormatDJTX(i.balance-i.spendableBalance))})
+		}
+	}
+	if len(i.zeroFees) > 0 {
+		tb.Append([]string{formatter.F("{{yellow}}{{bold}}ZERO FEE WARNING{{/}}"), formatter.F("{{yellow}}%s unexpectedly zero on %s (misconfigured node?){{/}}", strings.Join(i.zeroFees, ", "), i.networkName)})
+	}
 	if i.txFee > 0 {
 		txFee := float64(i.txFee) / float64(units.Djtx)
 		txFees := humanize.FormatFloat("#,###.###", txFee)
@@ -193,47 +401,105 @@ func BaseTableSetup(i *Info) (*bytes.Buffer, *tablewriter.Table) {
 
 	tb.Append([]string{formatter.F("{{orange}}URI{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.uri)})
 	tb.Append([]string{formatter.F("{{orange}}NETWORK NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.networkName)})
+
+	pendings := []string{}
+	for _, nodeID := range i.allNodeIDs {
+		valInfo, ok := i.valInfos[nodeID]
+		if !ok || !valInfo.pending {
+			continue
+		}
+		pendings = append(pendings, fmt.Sprintf("%s (starts at %s)", nodeID, valInfo.start.Format(time.RFC3339)))
+	}
+	if len(pendings) > 0 {
+		tb.Append([]string{formatter.F("{{yellow}}PENDING VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(pendings, "\n"))})
+	}
 	return buf, tb
 }
 
 func ParseNodeIDs(cli client.Client, i *Info) error {
 	// TODO: make this parsing logic more explicit (+ store per subnetID, not
 	// just whatever was called last)
+	rnodeIDs := make([]string, len(nodeIDs), len(nodeIDs)+len(nodeCertPaths))
+	copy(rnodeIDs, nodeIDs)
+	for _, certPath := range nodeCertPaths {
+		certPEM, err := os.ReadFile(certPath)
+		if err != nil {
+			return err
+		}
+		nodeID, err := key.NodeIDFromCert(certPEM)
+		if err != nil {
+			return fmt.Errorf("%w (%q)", err, certPath)
+		}
+		rnodeIDs = append(rnodeIDs, client.FormatNodeID(nodeID))
+	}
+
 	i.nodeIDs = []ids.ShortID{}
-	i.allNodeIDs = make([]ids.ShortID, len(nodeIDs))
-	for idx, rnodeID := range nodeIDs {
-		nodeID, err := ids.ShortFromPrefixedString(rnodeID, constants.NodeIDPrefix)
+	i.allNodeIDs = make([]ids.ShortID, len(rnodeIDs))
+	for idx, rnodeID := range rnodeIDs {
+		nodeID, err := client.ParseNodeID(rnodeID)
 		if err != nil {
 			return err
 		}
 		i.allNodeIDs[idx] = nodeID
+	}
+	if len(i.allNodeIDs) == 0 {
+		return nil
+	}
 
-		start, end, err := cli.P().GetValidator(context.Background(), i.subnetID, nodeID)
-		i.valInfos[nodeID] = &ValInfo{start, end}
-		switch {
-		case errors.Is(err, client.ErrValidatorNotFound):
-			i.nodeIDs = append(i.nodeIDs, nodeID)
-		case err != nil:
-			return err
-		default:
+	// look up all the node IDs at once, instead of one request per node
+	vals, err := cli.P().GetValidators(context.Background(), i.subnetID, i.allNodeIDs)
+	if err != nil {
+		return err
+	}
+	notValidating := make([]ids.ShortID, 0, len(i.allNodeIDs))
+	for _, nodeID := range i.allNodeIDs {
+		val, found := vals[nodeID]
+		i.valInfos[nodeID] = &ValInfo{start: val.Start, end: val.End}
+		if found {
 			color.Outf("\n{{yellow}}%s is already a validator on %s{{/}}\n", nodeID, i.subnetID)
+			continue
+		}
+		notValidating = append(notValidating, nodeID)
+	}
+	if len(notValidating) == 0 {
+		return nil
+	}
+
+	// not validating yet, but may have been added already
+	pendings, err := cli.P().GetPendingValidators(context.Background(), i.subnetID, notValidating)
+	if err != nil {
+		return err
+	}
+	for _, nodeID := range notValidating {
+		pending, found := pendings[nodeID]
+		if !found {
+			i.nodeIDs = append(i.nodeIDs, nodeID)
+			continue
 		}
+		i.valInfos[nodeID] = &ValInfo{start: pending.Start, end: pending.End, pending: true}
+		color.Outf("\n{{yellow}}%s is already a pending validator on %s (starts at %s){{/}}\n", nodeID, i.subnetID, pending.Start.Format(time.RFC3339))
 	}
 	return nil
 }
 
+func printBootstrapProgress(p internal_platformvm.BootstrapProgress) {
+	if p.Percent < 0 {
+		color.Outf("{{yellow}}waiting for blockchain to bootstrap...{{/}} {{light-gray}}(elapsed %v){{/}}\n", p.Elapsed.Round(time.Second))
+		return
+	}
+	color.Outf("{{yellow}}waiting for blockchain to bootstrap... %.1f%%{{/}} {{light-gray}}(elapsed %v, ETA %v){{/}}\n", p.Percent, p.Elapsed.Round(time.Second), p.ETA)
+}
+
 func WaitValidator(cli client.Client, nodeIDs []ids.ShortID, i *Info) {
 	for _, nodeID := range nodeIDs {
 		color.Outf("{{yellow}}waiting for validator %s to start validating %s...(could take a few minutes){{/}}\n", nodeID, i.subnetID)
-		for {
-			start, end, err := cli.P().GetValidator(context.Background(), i.subnetID, nodeID)
-			if err == nil {
-				if i.subnetID == ids.Empty {
-					i.valInfos[nodeID] = &ValInfo{start, end}
-				}
-				break
-			}
-			time.Sleep(10 * time.Second)
+		start, end, err := cli.P().GetValidatorWithRetry(context.Background(), i.subnetID, nodeID, requestTimeout)
+		if err != nil {
+			color.Outf("{{red}}failed to confirm validator %s: %v{{/}}\n", nodeID, err)
+			continue
+		}
+		if i.subnetID == ids.Empty {
+			i.valInfos[nodeID] = &ValInfo{start: start, end: end}
 		}
 	}
 }
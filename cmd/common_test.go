@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+
+	"github.com/lasthyphen/subnet-cli/internal/key"
+)
+
+type fakeKey struct {
+	idx    uint32
+	closed bool
+}
+
+func (k *fakeKey) P() string                  { return fmt.Sprintf("P-fake%d", k.idx) }
+func (k *fakeKey) Address() ids.ShortID       { return ids.ShortID{byte(k.idx)} }
+func (k *fakeKey) PublicKey() ([]byte, error) { return nil, nil }
+func (k *fakeKey) Spends([]*djtx.UTXO, ...key.OpOption) (uint64, []*djtx.TransferableInput) {
+	return 0, nil
+}
+func (k *fakeKey) Sign(context.Context, *platformvm.Tx, int) error { return nil }
+func (k *fakeKey) SignHash(context.Context, ids.ShortID, []byte) ([]byte, error) {
+	return nil, key.ErrUnknownSigner
+}
+func (k *fakeKey) Close() error {
+	k.closed = true
+	return nil
+}
+
+func TestSelectLedgerKey(t *testing.T) {
+	t.Parallel()
+
+	keys := []*fakeKey{}
+	hk, balance, err := selectLedgerKey(
+		ledgerIndexSelector(2),
+		func(i uint32) (key.Key, error) {
+			k := &fakeKey{idx: i}
+			keys = append(keys, k)
+			return k, nil
+		},
+		func(k key.Key) (uint64, error) {
+			return uint64(k.(*fakeKey).idx) * 100, nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hk.P() != "P-fake2" || balance != 200 {
+		t.Fatalf("unexpected key %q with balance %d, expected %q with balance %d", hk.P(), balance, "P-fake2", 200)
+	}
+	for i, k := range keys {
+		if closed := i != 2; k.closed != closed {
+			t.Fatalf("#%d: unexpected closed %v, expected %v", i, k.closed, closed)
+		}
+	}
+}
+
+func TestCheckKeyNetwork(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		keyNetwork  string
+		networkID   uint32
+		expectedErr error
+	}{
+		{name: "no key network", networkID: constants.MainnetID},
+		{name: "same network", keyNetwork: "mainnet", networkID: constants.MainnetID},
+		{name: "tahoe key on mainnet", keyNetwork: "tahoe", networkID: constants.MainnetID, expectedErr: ErrNetworkKeyMismatch},
+		{name: "custom networks share the HRP", keyNetwork: "network-1337", networkID: 54321},
+		{name: "mainnet key on custom", keyNetwork: "mainnet", networkID: 1337, expectedErr: ErrNetworkKeyMismatch},
+		{name: "invalid key network", keyNetwork: "network-x", networkID: constants.MainnetID, expectedErr: ErrNetworkKeyMismatch},
+	}
+	for i, tv := range tt {
+		if err := checkKeyNetwork(tv.keyNetwork, tv.networkID); !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+	}
+}
+
+func TestReadKeyNetwork(t *testing.T) {
+	t.Parallel()
+
+	keyPath := filepath.Join(t.TempDir(), "test.key")
+	if n, err := readKeyNetwork(keyPath); n != "" || err != nil {
+		t.Fatalf("unexpected key network %q (%v), expected none", n, err)
+	}
+	if err := os.WriteFile(keyPath+keyNetworkSuffix, []byte("tahoe\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := readKeyNetwork(keyPath); n != "tahoe" || err != nil {
+		t.Fatalf("unexpected key network %q (%v), expected %q", n, err, "tahoe")
+	}
+}
@@ -4,6 +4,8 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"github.com/spf13/cobra"
@@ -24,6 +26,7 @@ func CreateCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://dijets.ukwest.cloudapp.azure.com:443/", "URI for avalanche network endpoints")
 	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
 	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().IntVar(&ledgerAccountIndex, "ledger-account-index", -1, "ledger account index to use without prompting (default to prompt)")
 	return cmd
 }
 
@@ -32,6 +35,10 @@ func MakeCreateTable(i *Info) string {
 	if i.subnetID != ids.Empty {
 		tb.Append([]string{formatter.F("{{blue}}%s{{/}}", i.subnetIDType), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.subnetID)})
 	}
+	if len(i.subnetControlAddrs) > 0 {
+		tb.Append([]string{formatter.F("{{blue}}SUBNET CONTROL ADDRESSES{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(i.subnetControlAddrs, "\n"))})
+		tb.Append([]string{formatter.F("{{blue}}SUBNET CONTROL THRESHOLD{{/}}"), formatter.F("{{light-gray}}{{bold}}%d of %d{{/}}", i.subnetThreshold, len(i.subnetControlAddrs))})
+	}
 	if i.blockchainID != ids.Empty {
 		tb.Append([]string{formatter.F("{{blue}}CREATED BLOCKCHAIN ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.blockchainID)})
 	}
@@ -39,6 +46,7 @@ func MakeCreateTable(i *Info) string {
 		tb.Append([]string{formatter.F("{{dark-green}}CHAIN NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.chainName)})
 		tb.Append([]string{formatter.F("{{dark-green}}VM ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmID)})
 		tb.Append([]string{formatter.F("{{dark-green}}VM GENESIS PATH{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmGenesisPath)})
+		tb.Append([]string{formatter.F("{{dark-green}}VM GENESIS HASH{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmGenesisHash)})
 	}
 	tb.Render()
 	return buf.String()
@@ -6,10 +6,11 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
 	"os"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/subnet-cli/client"
+	"github.com/lasthyphen/subnet-cli/internal/genesis"
 	"github.com/lasthyphen/subnet-cli/pkg/color"
 	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
@@ -26,7 +27,7 @@ Creates a blockchain.
 $ subnet-cli create blockchain \
 --private-key-path=.insecure.ewoq.key \
 --subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
---chain-name=my-custom-chain \
+--chain-name=mycustomchain \
 --vm-id=tGas3T58KzdjLHhBDMnH2TvrddhqTji5iZAMZ3RXs2NLpSnhH \
 --vm-genesis-path=.my-custom-vm.genesis
 
@@ -37,7 +38,8 @@ $ subnet-cli create blockchain \
 	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
 	cmd.PersistentFlags().StringVar(&chainName, "chain-name", "", "chain name")
 	cmd.PersistentFlags().StringVar(&vmIDs, "vm-id", "", "VM ID (must be formatted in ids.ID)")
-	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-path", "", "VM genesis file path")
+	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-path", "", "VM genesis file path or https:// URL")
+	cmd.PersistentFlags().BoolVar(&verifyVMInstalled, "verify-vm-installed", false, "fail if the VM is not installed on the node, rather than only warning")
 
 	return cmd
 }
@@ -47,6 +49,7 @@ func createBlockchainFunc(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	defer info.Close()
 	info.subnetIDType = "SUBNET ID"
 	info.subnetID, err = ids.FromString(subnetIDs)
 	if err != nil {
@@ -56,17 +59,29 @@ func createBlockchainFunc(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	vmGenesisBytes, err := ioutil.ReadFile(vmGenesisPath)
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+	vmGenesisBytes, err := genesis.Load(ctx, vmGenesisPath)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if err := genesis.ValidatePrecompiles(vmGenesisBytes); err != nil {
+		return err
+	}
+	info.txFee = client.Fee(info.feeData, client.OperationCreateBlockchain)
+	info.requiredBalance, err = client.RequiredBalance(client.OperationCreateBlockchain, info.txFee, 0)
 	if err != nil {
 		return err
 	}
-	info.txFee = uint64(info.feeData.CreateBlockchainTxFee)
-	info.requiredBalance = info.txFee
-	if err := info.CheckBalance(); err != nil {
+	if err := info.CheckBalance(cli); err != nil {
+		return err
+	}
+	info.chainName, err = client.ValidateChainName(chainName)
+	if err != nil {
 		return err
 	}
-	info.chainName = chainName
 	info.vmGenesisPath = vmGenesisPath
+	info.vmGenesisHash = client.GenesisHash(vmGenesisBytes)
 
 	msg := MakeCreateTable(info)
 	if enablePrompt {
@@ -94,27 +109,30 @@ func createBlockchainFunc(cmd *cobra.Command, args []string) error {
 	println()
 	println()
 	println()
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-	blockchainID, took, err := cli.P().CreateBlockchain(
+	ctx, cancel = context.WithTimeout(cmd.Context(), requestTimeout)
+	blockchainID, genesisHash, took, err := cli.P().CreateBlockchain(
 		ctx,
 		info.key,
 		info.subnetID,
 		info.chainName,
 		info.vmID,
 		vmGenesisBytes,
+		client.WithBootstrapProgress(printBootstrapProgress),
+		client.WithUniqueChainName(true),
+		client.WithVerifyVMInstalled(verifyVMInstalled),
+		client.WithAuditLog(auditLogPath),
 	)
 	cancel()
 	if err != nil {
 		return err
 	}
 	info.blockchainID = blockchainID
-	color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(took %v){{/}}\n\n", info.blockchainID, took)
+	color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(genesis hash %s, took %v){{/}}\n\n", info.blockchainID, genesisHash, took)
 
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.txFee = 0
-	info.balance, err = cli.P().Balance(context.Background(), info.key)
-	if err != nil {
+	if err := info.RefreshBalances(cli); err != nil {
 		return err
 	}
 	fmt.Fprint(formatter.ColorableStdOut, MakeCreateTable(info))
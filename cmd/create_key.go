@@ -6,6 +6,8 @@ package cmd
 import (
 	"os"
 
+	"github.com/lasthyphen/dijetsnodego/utils/constants"
+
 	"github.com/lasthyphen/subnet-cli/internal/key"
 	"github.com/lasthyphen/subnet-cli/pkg/color"
 	"github.com/spf13/cobra"
@@ -20,9 +22,15 @@ Generates a private key.
 
 $ subnet-cli create key --private-key-path=.insecure.test.key
 
+With "--network", the network is stored next to the key (in
+".insecure.test.key.network"), to refuse using the key on any other.
+
+$ subnet-cli create key --private-key-path=.insecure.test.key --network=tahoe
+
 `,
 		RunE: createKeyFunc,
 	}
+	cmd.PersistentFlags().BoolVar(&showKeyBackup, "show-backup", false, "'true' to print the new key in hex and CB58 with its address, for backup")
 	return cmd
 }
 
@@ -31,7 +39,15 @@ func createKeyFunc(cmd *cobra.Command, args []string) error {
 		color.Outf("{{red}}key already found at %q{{/}}\n", privKeyPath)
 		return os.ErrExist
 	}
-	k, err := key.NewSoft(0)
+	networkID := uint32(0)
+	if expectedNetwork != "" {
+		var err error
+		networkID, err = constants.NetworkID(expectedNetwork)
+		if err != nil {
+			return err
+		}
+	}
+	k, err := key.NewSoft(networkID)
 	if err != nil {
 		return err
 	}
@@ -39,5 +55,18 @@ func createKeyFunc(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	color.Outf("{{green}}created a new key %q{{/}}\n", privKeyPath)
+	if expectedNetwork != "" {
+		if err := os.WriteFile(privKeyPath+keyNetworkSuffix, []byte(expectedNetwork+"\n"), 0o600); err != nil {
+			return err
+		}
+		color.Outf("{{green}}stored the key network %q{{/}}\n", expectedNetwork)
+	}
+	if showKeyBackup {
+		hexKey, cb58Key, addr := k.Backup()
+		color.Outf("\n{{red}}{{bold}}keep the following secret, anyone with the key controls its funds{{/}}\n")
+		color.Outf("{{cyan}}hex:{{/}} %s\n", hexKey)
+		color.Outf("{{cyan}}cb58:{{/}} %s\n", cb58Key)
+		color.Outf("{{cyan}}P-Chain address:{{/}} %s\n", addr)
+	}
 	return nil
 }
@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -26,36 +27,53 @@ $ subnet-cli create subnet \
 --private-key-path=.insecure.ewoq.key \
 --public-uri=http://localhost:52250
 
+With "--json", the created subnet and its owner (as read back from the
+accepted tx) are printed as JSON instead of the tables.
+
 `,
 		RunE: createSubnetFunc,
 	}
 
+	cmd.PersistentFlags().BoolVar(&outputJSON, "json", false, "'true' to print the created subnet and its control addresses as JSON")
 	return cmd
 }
 
+// createSubnetOutput is the "create subnet --json" output.
+type createSubnetOutput struct {
+	SubnetID string `json:"subnetId"`
+	*client.SubnetOwner
+}
+
 func createSubnetFunc(cmd *cobra.Command, args []string) error {
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-	sid, _, err := cli.P().CreateSubnet(ctx, info.key, client.WithDryMode(true))
+	defer info.Close()
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+	sid, _, _, err := cli.P().CreateSubnet(ctx, info.key, client.WithDryMode(true))
 	cancel()
 	if err != nil {
 		return err
 	}
-	info.txFee = uint64(info.feeData.CreateSubnetTxFee)
+	info.txFee = client.Fee(info.feeData, client.OperationCreateSubnet)
+	info.requiredBalance, err = client.RequiredBalance(client.OperationCreateSubnet, info.txFee, 0)
+	if err != nil {
+		return err
+	}
 	info.subnetIDType = "EXPECTED SUBNET ID"
 	info.subnetID = sid
-	if err := info.CheckBalance(); err != nil {
+	if err := info.CheckBalance(cli); err != nil {
 		return err
 	}
 
-	msg := MakeCreateTable(info)
-	if enablePrompt {
-		msg = formatter.F("\n{{blue}}{{bold}}Ready to create subnet resources, should we continue?{{/}}\n") + msg
+	if enablePrompt || !outputJSON {
+		msg := MakeCreateTable(info)
+		if enablePrompt {
+			msg = formatter.F("\n{{blue}}{{bold}}Ready to create subnet resources, should we continue?{{/}}\n") + msg
+		}
+		fmt.Fprint(formatter.ColorableStdOut, msg)
 	}
-	fmt.Fprint(formatter.ColorableStdOut, msg)
 
 	if enablePrompt {
 		prompt := promptui.Select{
@@ -75,17 +93,29 @@ func createSubnetFunc(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	println()
-	println()
-	println()
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
-	subnetID, took, err := cli.P().CreateSubnet(ctx, info.key)
+	if !outputJSON {
+		println()
+		println()
+		println()
+	}
+	ctx, cancel = context.WithTimeout(cmd.Context(), requestTimeout)
+	subnetID, owner, took, err := cli.P().CreateSubnet(ctx, info.key, client.WithAuditLog(auditLogPath))
 	cancel()
 	if err != nil {
 		return err
 	}
 	info.subnetIDType = "CREATED SUBNET ID"
 	info.subnetID = subnetID
+	info.subnetThreshold, info.subnetControlAddrs = owner.Threshold, owner.ControlAddrs
+
+	if outputJSON {
+		b, err := json.MarshalIndent(createSubnetOutput{SubnetID: subnetID.String(), SubnetOwner: owner}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
 
 	color.Outf("{{magenta}}created subnet{{/}} %q {{light-gray}}(took %v){{/}}\n", info.subnetID, took)
 	color.Outf("({{orange}}subnet must be whitelisted beforehand via{{/}} {{cyan}}{{bold}}--whitelisted-subnets{{/}} {{orange}}flag!{{/}})\n\n")
@@ -93,8 +123,7 @@ func createSubnetFunc(cmd *cobra.Command, args []string) error {
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.txFee = 0
-	info.balance, err = cli.P().Balance(context.Background(), info.key)
-	if err != nil {
+	if err := info.RefreshBalances(cli); err != nil {
 		return err
 	}
 	fmt.Fprint(formatter.ColorableStdOut, MakeCreateTable(info))
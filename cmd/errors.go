@@ -7,4 +7,7 @@ import (
 	"errors"
 )
 
-var ErrInsufficientFunds = errors.New("insufficient funds")
+var (
+	ErrInsufficientFunds  = errors.New("insufficient funds")
+	ErrNetworkKeyMismatch = errors.New("key does not match network")
+)
@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/lasthyphen/subnet-cli/pkg/color"
+	"github.com/spf13/cobra"
+)
+
+func FaucetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "faucet",
+		Short: "Requests test funds for the key from the faucet",
+		Long: `
+Requests test funds for the P-Chain address of the key from the faucet
+of the test network (not available on mainnet).
+
+$ subnet-cli faucet \
+--private-key-path=.subnet-cli.pk \
+--public-uri=https://dijets.ukwest.cloudapp.azure.com:443
+
+`,
+		RunE: faucetFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://dijets.ukwest.cloudapp.azure.com:443/", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().IntVar(&ledgerAccountIndex, "ledger-account-index", -1, "ledger account index to use without prompting (default to prompt)")
+	cmd.PersistentFlags().StringVar(&faucetURL, "faucet-url", "", "faucet to request the funds from (default to the Tahoe faucet, required on local networks)")
+	return cmd
+}
+
+func faucetFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	defer info.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+	reply, err := cli.RequestTestnetFunds(ctx, info.key.P())
+	cancel()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{magenta}}requested test funds for{{/}} %q {{light-gray}}(tx %s){{/}}\n", info.key.P(), reply.TxID)
+	if reply.Message != "" {
+		color.Outf("{{light-gray}}%s{{/}}\n", reply.Message)
+	}
+	return nil
+}
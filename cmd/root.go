@@ -4,6 +4,10 @@
 package cmd
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -21,8 +25,10 @@ var (
 	enablePrompt bool
 	logLevel     string
 
-	privKeyPath string
-	useLedger   bool // TODO: specify starting index
+	privKeyPath        string
+	useLedger          bool
+	ledgerAccountIndex int
+	ledgerAddressCount uint32
 
 	privateURI string
 	publicURI  string
@@ -30,12 +36,25 @@ var (
 	pollInterval   time.Duration
 	requestTimeout time.Duration
 
-	subnetIDs   string
-	nodeIDs     []string
-	stakeAmount uint64
+	lowBalanceWarning string
+	assetIDs          string
+	pChainIDs         string
+	auditLogPath      string
+	faucetURL         string
+	expectedNetwork   string
+	showKeyBackup     bool
+	strictVersion     bool
+
+	subnetIDs     string
+	nodeIDs       []string
+	nodeCertPaths []string
+	stakeAmounts  string
 
 	validateEnds             string
 	validateWeight           uint64
+	minUptimePercent         float64
+	requireConnected         bool
+	verifyVMInstalled        bool
 	validateRewardFeePercent uint32
 
 	rewardAddrs string
@@ -47,6 +66,13 @@ var (
 
 	blockchainID      string
 	checkBootstrapped bool
+
+	expectedThreshold uint32
+	expectedOwners    []string
+
+	manifestPath string
+
+	outputJSON bool
 )
 
 func init() {
@@ -57,17 +83,28 @@ func init() {
 		AddCommand(),
 		StatusCommand(),
 		WizardCommand(),
+		FaucetCommand(),
 	)
 
 	rootCmd.PersistentFlags().BoolVar(&enablePrompt, "enable-prompt", true, "'true' to enable prompt mode")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", logutil.DefaultLogLevel, "log level")
 	rootCmd.PersistentFlags().DurationVar(&pollInterval, "poll-interval", time.Second, "interval to poll tx/blockchain status")
 	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", 2*time.Minute, "request timeout")
+	rootCmd.PersistentFlags().StringVar(&expectedNetwork, "network", "", "network the node must be on (e.g., mainnet, tahoe, local), to refuse any other (default to the network of the node)")
+	rootCmd.PersistentFlags().BoolVar(&strictVersion, "strict-version-check", false, "'true' to fail if the node version is not supported, instead of warning")
+	rootCmd.PersistentFlags().StringVar(&assetIDs, "asset-id", "", "DJTX asset ID of the network, to skip discovering it from the X-Chain")
+	rootCmd.PersistentFlags().StringVar(&pChainIDs, "p-chain-id", "", "P-Chain ID of the network, for custom deployments (default to the standard platform chain ID)")
+	rootCmd.PersistentFlags().StringVar(&auditLogPath, "audit-log", "", "file to append a JSON line to for every tx issued (disabled if empty)")
+	rootCmd.PersistentFlags().StringVar(&lowBalanceWarning, "low-balance-warning", "", "DJTX balance left after an operation to warn below (default to 3x the tx fee)")
 }
 
 func Execute() error {
 	if err := CreateLogger(); err != nil {
 		return err
 	}
-	return rootCmd.Execute()
+	// cancel the in-flight requests (e.g., a pending Ledger signature)
+	// on interrupt, rather than leaving the process blocked
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
 }
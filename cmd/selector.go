@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/manifoldco/promptui"
+)
+
+// Selector prompts to select one of the [items], and returns its index.
+type Selector interface {
+	Select(label string, items []string) (int, error)
+}
+
+// TerminalSelector prompts on the terminal.
+type TerminalSelector struct{}
+
+func (TerminalSelector) Select(label string, items []string) (int, error) {
+	prompt := promptui.Select{
+		Label:  label,
+		Stdout: os.Stdout,
+		Items:  items,
+	}
+	idx, _, err := prompt.Run()
+	return idx, err
+}
+
+// SelectorFunc adapts a function to "Selector",
+// e.g., to select programmatically in tests.
+type SelectorFunc func(label string, items []string) (int, error)
+
+func (f SelectorFunc) Select(label string, items []string) (int, error) {
+	return f(label, items)
+}
+
+var selector Selector = TerminalSelector{}
+
+// SetSelector replaces the terminal prompt used to select the Ledger
+// address, e.g., for non-TTY environments.
+func SetSelector(s Selector) {
+	selector = s
+}
+
+// ledgerIndexSelector selects the Ledger address at [accountIndex]
+// without prompting, by trying the next address until reached.
+func ledgerIndexSelector(accountIndex uint32) Selector {
+	cur := uint32(0)
+	return SelectorFunc(func(string, []string) (int, error) {
+		if cur == accountIndex {
+			return 0, nil
+		}
+		cur++
+		return 1, nil
+	})
+}
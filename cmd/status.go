@@ -15,6 +15,11 @@ func StatusCommand() *cobra.Command {
 	}
 	cmd.AddCommand(
 		newStatusBlockchainCommand(),
+		newStatusUnlockScheduleCommand(),
+		newStatusSubnetOwnersCommand(),
+		newStatusLedgerAddressesCommand(),
+		newStatusVerifyCommand(),
+		newStatusVMsCommand(),
 	)
 	cmd.PersistentFlags().StringVar(&privateURI, "private-uri", "", "URI for avalanche network endpoints")
 	return cmd
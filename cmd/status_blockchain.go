@@ -51,11 +51,14 @@ func createStatusFunc(cmd *cobra.Command, args []string) error {
 		internal_platformvm.WithBlockchainStatus(pstatus.Validating),
 	}
 	if checkBootstrapped {
-		opts = append(opts, internal_platformvm.WithCheckBlockchainBootstrapped(cli.Info().Client()))
+		opts = append(opts,
+			internal_platformvm.WithCheckBlockchainBootstrapped(cli.Info().Client()),
+			internal_platformvm.WithBootstrapProgress(printBootstrapProgress),
+		)
 	}
 
 	color.Outf("\n{{blue}}Checking blockchain...{{/}}\n")
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
 	_, err = cli.P().Checker().PollBlockchain(ctx, opts...)
 	cancel()
 	return err
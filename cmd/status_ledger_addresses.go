@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/lasthyphen/subnet-cli/internal/key"
+	"github.com/lasthyphen/subnet-cli/pkg/amount"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+)
+
+func newStatusLedgerAddressesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ledger-addresses",
+		Short: "Lists the Ledger addresses and their balances",
+		Long: `
+Lists the first Ledger addresses and their balances, to pick the
+"--ledger-account-index" to use.
+
+$ subnet-cli status ledger-addresses \
+--private-uri=http://localhost:49738 \
+--count=5
+
+`,
+		RunE: createLedgerAddressesFunc,
+	}
+
+	cmd.PersistentFlags().Uint32Var(&ledgerAddressCount, "count", 5, "number of addresses to derive")
+	return cmd
+}
+
+func createLedgerAddressesFunc(cmd *cobra.Command, args []string) error {
+	cli, _, err := InitClient(privateURI, false)
+	if err != nil {
+		return err
+	}
+
+	hk, err := key.NewHard(cli.NetworkID(), 0)
+	if err != nil {
+		return err
+	}
+	defer hk.Close()
+
+	// print the addresses derived before a failure (e.g., rejected on the device)
+	pAddrs, _, derr := hk.DeriveAddresses(ledgerAddressCount)
+
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+	tb.SetRowLine(true)
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	tb.SetHeader([]string{
+		formatter.F("{{cyan}}{{bold}}INDEX{{/}}"),
+		formatter.F("{{cyan}}{{bold}}P-CHAIN ADDRESS{{/}}"),
+		formatter.F("{{coral}}{{bold}}P-CHAIN BALANCE{{/}}"),
+	})
+	for i, pAddr := range pAddrs {
+		ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+		pb, err := cli.P().Client().GetBalance(ctx, []string{pAddr})
+		cancel()
+		if err != nil {
+			return err
+		}
+		tb.Append([]string{
+			fmt.Sprintf("%d", i),
+			formatter.F("{{light-gray}}{{bold}}%s{{/}}", pAddr),
+			formatter.F("{{light-gray}}{{bold}}%s{{/}} $DJTX", amount.FormatDJTX(uint64(pb.Balance))),
+		})
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	return derr
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/formatting"
+	"github.com/lasthyphen/subnet-cli/pkg/color"
+	"github.com/spf13/cobra"
+)
+
+var errInvalidOwnerAddress = errors.New("invalid owner address")
+
+func newStatusSubnetOwnersCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subnet-owners",
+		Short: "Verifies the control keys of a subnet",
+		Long: `
+Verifies that the subnet is controlled by the expected threshold
+of the expected P-Chain addresses.
+
+$ subnet-cli status subnet-owners \
+--private-uri=http://localhost:49738 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--expected-threshold=2 \
+--expected-owners="P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p,P-custom1..."
+
+`,
+		RunE: createSubnetOwnersFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID to verify")
+	cmd.PersistentFlags().Uint32Var(&expectedThreshold, "expected-threshold", 1, "expected number of signatures required to manage the subnet")
+	cmd.PersistentFlags().StringSliceVar(&expectedOwners, "expected-owners", nil, "expected P-Chain control addresses of the subnet")
+	return cmd
+}
+
+func createSubnetOwnersFunc(cmd *cobra.Command, args []string) error {
+	cli, _, err := InitClient(privateURI, false)
+	if err != nil {
+		return err
+	}
+
+	subnetID, err := ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+	owners := make([]ids.ShortID, len(expectedOwners))
+	for i, addr := range expectedOwners {
+		_, _, b, err := formatting.ParseAddress(addr)
+		if err != nil {
+			return fmt.Errorf("%w: %q (%v)", errInvalidOwnerAddress, addr, err)
+		}
+		owners[i], err = ids.ToShortID(b)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+	err = cli.P().VerifySubnetOwners(ctx, subnetID, expectedThreshold, owners)
+	cancel()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{green}}subnet %s is controlled by %d of %v{{/}}\n", subnetID, expectedThreshold, expectedOwners)
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+	"github.com/lasthyphen/subnet-cli/client"
+	"github.com/lasthyphen/subnet-cli/pkg/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+)
+
+func newStatusUnlockScheduleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unlock-schedule",
+		Short: "Shows when the stakeable-locked funds unlock",
+		Long: `
+Lists the stakeable-locked amounts of the key grouped by unlock time.
+
+$ subnet-cli status unlock-schedule \
+--private-key-path=.insecure.ewoq.key \
+--private-uri=http://localhost:49738
+
+`,
+		RunE: createUnlockScheduleFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().IntVar(&ledgerAccountIndex, "ledger-account-index", -1, "ledger account index to use without prompting (default to prompt)")
+	return cmd
+}
+
+func createUnlockScheduleFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := InitClient(privateURI, true)
+	if err != nil {
+		return err
+	}
+	defer info.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+	events, err := cli.P().UnlockSchedule(ctx, info.key)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		color.Outf("{{green}}no stakeable-locked funds for{{/}} %q\n", info.key.P())
+		return nil
+	}
+	fmt.Fprint(formatter.ColorableStdOut, MakeUnlockScheduleTable(info, events))
+	return nil
+}
+
+func MakeUnlockScheduleTable(i *Info, events []client.UnlockEvent) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+
+	tb.SetRowLine(true)
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	tb.SetHeader([]string{
+		formatter.F("{{cyan}}{{bold}}UNLOCK TIME{{/}}"),
+		formatter.F("{{coral}}{{bold}}AMOUNT{{/}}"),
+		formatter.F("{{coral}}{{bold}}CUMULATIVE{{/}}"),
+	})
+	cumulative := uint64(0)
+	for _, ev := range events {
+		cumulative += ev.Amount
+		amount := humanize.FormatFloat("#,###.#######", float64(ev.Amount)/float64(units.Djtx))
+		total := humanize.FormatFloat("#,###.#######", float64(cumulative)/float64(units.Djtx))
+		tb.Append([]string{
+			formatter.F("{{light-gray}}{{bold}}%s{{/}} (%v)", ev.Time.UTC().Format(time.RFC3339), humanize.Time(ev.Time)),
+			formatter.F("{{light-gray}}{{bold}}%s{{/}} $DJTX", amount),
+			formatter.F("{{light-gray}}{{bold}}%s{{/}} $DJTX", total),
+		})
+	}
+	tb.SetFooter([]string{formatter.F("{{orange}}P-CHAIN ADDRESS{{/}}"), i.key.P(), ""})
+	tb.Render()
+	return buf.String()
+}
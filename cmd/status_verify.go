@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lasthyphen/subnet-cli/client"
+	"github.com/lasthyphen/subnet-cli/pkg/color"
+	"github.com/spf13/cobra"
+)
+
+var errDeployDrift = errors.New("deployment drifted from manifest")
+
+func newStatusVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies a deployment against its manifest",
+		Long: `
+Verifies that the subnet, blockchains, and validators on-chain
+match the intended state in the manifest.
+
+$ subnet-cli status verify \
+--private-uri=http://localhost:49738 \
+--manifest-path=.subnet-manifest.json
+
+`,
+		RunE: createVerifyFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&manifestPath, "manifest-path", "", "deploy manifest file path")
+	return cmd
+}
+
+func createVerifyFunc(cmd *cobra.Command, args []string) error {
+	cli, _, err := InitClient(privateURI, false)
+	if err != nil {
+		return err
+	}
+	m, err := client.LoadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+	r, err := cli.P().Verify(ctx, m)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if r.OK() {
+		color.Outf("{{green}}subnet %s matches the manifest{{/}}\n", m.SubnetID)
+		return nil
+	}
+	for _, d := range r.Drifts {
+		color.Outf("{{red}}drift: %s{{/}}\n", d)
+	}
+	return errDeployDrift
+}
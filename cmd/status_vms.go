@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+)
+
+func newStatusVMsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vms",
+		Short: "Lists the VMs installed on the node",
+		Long: `
+Lists the VM plugins installed on the node, to confirm it can run
+a blockchain before creating it.
+
+$ subnet-cli status vms \
+--private-uri=http://localhost:49738
+
+`,
+		RunE: createStatusVMsFunc,
+	}
+	return cmd
+}
+
+func createStatusVMsFunc(cmd *cobra.Command, args []string) error {
+	cli, _, err := InitClient(privateURI, false)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+	vms, err := cli.Info().GetVMs(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, MakeVMsTable(vms))
+	return nil
+}
+
+func MakeVMsTable(vms map[ids.ID][]string) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+
+	tb.SetRowLine(true)
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	tb.SetHeader([]string{
+		formatter.F("{{cyan}}{{bold}}VM ID{{/}}"),
+		formatter.F("{{coral}}{{bold}}ALIASES{{/}}"),
+	})
+	vmIDs := make([]ids.ID, 0, len(vms))
+	for vmID := range vms {
+		vmIDs = append(vmIDs, vmID)
+	}
+	sort.Slice(vmIDs, func(i, j int) bool { return vmIDs[i].String() < vmIDs[j].String() })
+	for _, vmID := range vmIDs {
+		tb.Append([]string{
+			formatter.F("{{light-gray}}{{bold}}%s{{/}}", vmID),
+			formatter.F("{{light-gray}}%s{{/}}", strings.Join(vms[vmID], ", ")),
+		})
+	}
+	tb.Render()
+	return buf.String()
+}
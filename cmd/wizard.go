@@ -7,7 +7,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"time"
 
@@ -19,6 +18,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/lasthyphen/subnet-cli/client"
+	"github.com/lasthyphen/subnet-cli/internal/genesis"
 	"github.com/lasthyphen/subnet-cli/pkg/color"
 )
 
@@ -34,6 +34,7 @@ func WizardCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://dijets.ukwest.cloudapp.azure.com:443/", "URI for avalanche network endpoints")
 	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
 	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().IntVar(&ledgerAccountIndex, "ledger-account-index", -1, "ledger account index to use without prompting (default to prompt)")
 
 	// "add validator"
 	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
@@ -43,7 +44,7 @@ func WizardCommand() *cobra.Command {
 	// "create blockchain"
 	cmd.PersistentFlags().StringVar(&chainName, "chain-name", "", "chain name")
 	cmd.PersistentFlags().StringVar(&vmIDs, "vm-id", "", "VM ID (must be formatted in ids.ID)")
-	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-path", "", "VM genesis file path")
+	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-path", "", "VM genesis file path or https:// URL")
 
 	return cmd
 }
@@ -53,6 +54,7 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	defer info.Close()
 
 	if len(nodeIDs) == 0 {
 		return errors.New("no NodeIDs provided")
@@ -63,7 +65,6 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	if err := ParseNodeIDs(cli, info); err != nil {
 		return err
 	}
-	info.stakeAmount = stakeAmount
 	info.validateEnd, err = time.Parse(time.RFC3339, validateEnds)
 	if err != nil {
 		return err
@@ -76,18 +77,64 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	vmGenesisBytes, err := ioutil.ReadFile(vmGenesisPath)
+	ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
+	vmGenesisBytes, err := genesis.Load(ctx, vmGenesisPath)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if err := genesis.ValidatePrecompiles(vmGenesisBytes); err != nil {
+		return err
+	}
+	info.chainName, err = client.ValidateChainName(chainName)
 	if err != nil {
 		return err
 	}
-	info.chainName = chainName
 	info.vmGenesisPath = vmGenesisPath
+	info.vmGenesisHash = client.GenesisHash(vmGenesisBytes)
 
 	// Compute dry run cost/actions for approval
-	info.stakeAmount = uint64(len(info.nodeIDs)) * defaultStakeAmount
-	info.txFee = uint64(info.feeData.CreateSubnetTxFee) + uint64(info.feeData.TxFee)*uint64(len(info.allNodeIDs)) + uint64(info.feeData.CreateBlockchainTxFee)
-	info.requiredBalance = info.stakeAmount + info.txFee
-	if err := info.CheckBalance(); err != nil {
+	ctx, cancel = context.WithTimeout(cmd.Context(), requestTimeout)
+	stakeAmount := cli.StakingParams(ctx).MinValidatorStake
+	cancel()
+	info.stakeAmount, err = client.RequiredBalanceN(client.OperationAddValidator, 0, stakeAmount, len(info.nodeIDs))
+	if err != nil {
+		return err
+	}
+	createSubnetFee := client.Fee(info.feeData, client.OperationCreateSubnet)
+	addValidatorFee := client.Fee(info.feeData, client.OperationAddValidator)
+	addSubnetValidatorFee := client.Fee(info.feeData, client.OperationAddSubnetValidator)
+	createBlockchainFee := client.Fee(info.feeData, client.OperationCreateBlockchain)
+	info.txFee, info.requiredBalance = 0, 0
+	for _, r := range []struct {
+		op       client.Operation
+		fee      uint64
+		stakeAmt uint64
+		n        int
+	}{
+		{client.OperationCreateSubnet, createSubnetFee, 0, 1},
+		{client.OperationAddValidator, addValidatorFee, stakeAmount, len(info.nodeIDs)},
+		{client.OperationAddSubnetValidator, addSubnetValidatorFee, 0, len(info.allNodeIDs)},
+		{client.OperationCreateBlockchain, createBlockchainFee, 0, 1},
+	} {
+		fee, err := client.RequiredBalanceN(r.op, r.fee, 0, r.n)
+		if err != nil {
+			return err
+		}
+		info.txFee, err = client.AddAmounts(info.txFee, fee)
+		if err != nil {
+			return err
+		}
+		required, err := client.RequiredBalanceN(r.op, r.fee, r.stakeAmt, r.n)
+		if err != nil {
+			return err
+		}
+		info.requiredBalance, err = client.AddAmounts(info.requiredBalance, required)
+		if err != nil {
+			return err
+		}
+	}
+	if err := info.CheckBalance(cli); err != nil {
 		return err
 	}
 
@@ -117,7 +164,7 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 
 	// Ensure all nodes are validators on the primary network
 	for i, nodeID := range info.nodeIDs {
-		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
 		info.validateStart = time.Now().Add(30 * time.Second)
 		took, err := cli.P().AddValidator(
 			ctx,
@@ -125,10 +172,11 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 			nodeID,
 			info.validateStart,
 			info.validateEnd,
-			client.WithStakeAmount(info.stakeAmount),
+			client.WithStakeAmount(stakeAmount),
 			client.WithRewardShares(info.validateRewardFeePercent*10000),
 			client.WithRewardAddress(info.rewardAddr),
 			client.WithChangeAddress(info.changeAddr),
+			client.WithAuditLog(auditLogPath),
 		)
 		cancel()
 		if err != nil {
@@ -146,13 +194,14 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create subnet
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-	subnetID, took, err := cli.P().CreateSubnet(ctx, info.key)
+	ctx, cancel = context.WithTimeout(cmd.Context(), requestTimeout)
+	subnetID, owner, took, err := cli.P().CreateSubnet(ctx, info.key, client.WithAuditLog(auditLogPath))
 	cancel()
 	if err != nil {
 		return err
 	}
 	info.subnetID = subnetID
+	info.subnetThreshold, info.subnetControlAddrs = owner.Threshold, owner.ControlAddrs
 	color.Outf("{{magenta}}created subnet{{/}} %q {{light-gray}}(took %v){{/}}\n", info.subnetID, took)
 
 	// Pause for operator to whitelist subnet on all validators (and to remind
@@ -178,7 +227,7 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 
 	// Add validators to subnet
 	for _, nodeID := range info.allNodeIDs { // do all nodes, not parsed
-		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		ctx, cancel := context.WithTimeout(cmd.Context(), requestTimeout)
 		valInfo := info.valInfos[nodeID]
 		start := time.Now().Add(30 * time.Second)
 		took, err := cli.P().AddSubnetValidator(
@@ -189,6 +238,7 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 			start,
 			valInfo.end,
 			validateWeight,
+			client.WithAuditLog(auditLogPath),
 		)
 		cancel()
 		if err != nil {
@@ -204,28 +254,29 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	println()
 
 	// Add blockchain to subnet
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
-	blockchainID, took, err := cli.P().CreateBlockchain(
+	ctx, cancel = context.WithTimeout(cmd.Context(), requestTimeout)
+	blockchainID, genesisHash, took, err := cli.P().CreateBlockchain(
 		ctx,
 		info.key,
 		info.subnetID,
 		info.chainName,
 		info.vmID,
 		vmGenesisBytes,
+		client.WithBootstrapProgress(printBootstrapProgress),
+		client.WithAuditLog(auditLogPath),
 	)
 	cancel()
 	if err != nil {
 		return err
 	}
 	info.blockchainID = blockchainID
-	color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(took %v){{/}}\n\n", info.blockchainID, took)
+	color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(genesis hash %s, took %v){{/}}\n\n", info.blockchainID, genesisHash, took)
 
 	// Print out summary of actions (subnetID, chainID, validator periods)
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.txFee = 0
-	info.balance, err = cli.P().Balance(context.Background(), info.key)
-	if err != nil {
+	if err := info.RefreshBalances(cli); err != nil {
 		return err
 	}
 	fmt.Fprint(formatter.ColorableStdOut, CreateSpellPostTable(info))
@@ -237,6 +288,7 @@ func CreateSpellPreTable(i *Info) string {
 	if len(i.nodeIDs) > 0 {
 		tb.Append([]string{formatter.F("{{magenta}}NEW PRIMARY NETWORK VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", i.nodeIDs)})
 		tb.Append([]string{formatter.F("{{magenta}}VALIDATE END{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.validateEnd.Format(time.RFC3339))})
+		tb.Append([]string{formatter.F("{{magenta}}VALIDATE DURATION{{/}}"), formatter.F("{{light-gray}}{{bold}}~%s{{/}}", client.FormatStakingDuration(time.Until(i.validateEnd)))})
 		stakeAmount := float64(i.stakeAmount) / float64(units.Djtx)
 		stakeAmounts := humanize.FormatFloat("#,###.###", stakeAmount)
 		tb.Append([]string{formatter.F("{{magenta}}STAKE AMOUNT{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}} $DJTX", stakeAmounts)})
@@ -252,6 +304,7 @@ func CreateSpellPreTable(i *Info) string {
 	tb.Append([]string{formatter.F("{{dark-green}}CHAIN NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.chainName)})
 	tb.Append([]string{formatter.F("{{dark-green}}VM ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmID)})
 	tb.Append([]string{formatter.F("{{dark-green}}VM GENESIS PATH{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmGenesisPath)})
+	tb.Append([]string{formatter.F("{{dark-green}}VM GENESIS HASH{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmGenesisHash)})
 	tb.Render()
 	return buf.String()
 }
@@ -269,6 +322,7 @@ func CreateSpellPostTable(i *Info) string {
 	tb.Append([]string{formatter.F("{{dark-green}}CHAIN NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.chainName)})
 	tb.Append([]string{formatter.F("{{dark-green}}VM ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmID)})
 	tb.Append([]string{formatter.F("{{dark-green}}VM GENESIS PATH{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmGenesisPath)})
+	tb.Append([]string{formatter.F("{{dark-green}}VM GENESIS HASH{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmGenesisHash)})
 	tb.Render()
 	return buf.String()
 }
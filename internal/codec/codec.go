@@ -4,6 +4,11 @@
 package codec
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
 	"github.com/lasthyphen/dijetsnodego/codec"
 	"github.com/lasthyphen/dijetsnodego/codec/linearcodec"
 	"github.com/lasthyphen/dijetsnodego/utils/wrappers"
@@ -11,11 +16,45 @@ import (
 	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
 )
 
+const (
+	// CodecVersion0 is the codec version that all the tx types
+	// currently built by this client are registered under.
+	CodecVersion0 uint16 = 0
+
+	// LatestCodecVersion is the newest codec version registered
+	// with "PCodecManager".
+	LatestCodecVersion = CodecVersion0
+)
+
+var ErrUnknownCodecVersion = errors.New("unknown codec version")
+
 var PCodecManager codec.Manager
 
+var (
+	versionsMu sync.RWMutex
+	// registered codec versions
+	versions = map[uint16]struct{}{}
+	// codec version to marshal each tx type with,
+	// if different from "defaultVersion"
+	txVersions     = map[reflect.Type]uint16{}
+	defaultVersion = LatestCodecVersion
+)
+
 func init() {
-	pc := linearcodec.NewDefault()
 	PCodecManager = codec.NewDefaultManager()
+	pc, err := NewCodec()
+	if err == nil {
+		err = RegisterCodecVersion(CodecVersion0, pc)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
+// NewCodec returns a codec with the types of "CodecVersion0" registered,
+// to extend with the types of a newer codec version.
+func NewCodec() (linearcodec.Codec, error) {
+	pc := linearcodec.NewDefault()
 	errs := wrappers.Errs{}
 	errs.Add(
 		pc.RegisterType(&platformvm.ProposalBlock{}),
@@ -41,9 +80,86 @@ func init() {
 		pc.RegisterType(&platformvm.UnsignedRewardValidatorTx{}),
 		pc.RegisterType(&platformvm.StakeableLockIn{}),
 		pc.RegisterType(&platformvm.StakeableLockOut{}),
-		PCodecManager.RegisterCodec(0, pc),
 	)
-	if errs.Errored() {
-		panic(errs.Err)
+	return pc, errs.Err
+}
+
+// RegisterCodecVersion registers [c] under [version] with "PCodecManager",
+// so that txs encoded with that version can be marshaled and unmarshaled.
+func RegisterCodecVersion(version uint16, c codec.Codec) error {
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+
+	if err := PCodecManager.RegisterCodec(version, c); err != nil {
+		return err
+	}
+	versions[version] = struct{}{}
+	return nil
+}
+
+// RegisterTxVersion marshals all txs of the same type as [utx] with
+// [version], instead of the default codec version.
+// e.g., a new tx type only registered with a newer codec version.
+func RegisterTxVersion(utx platformvm.UnsignedTx, version uint16) error {
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+
+	if _, ok := versions[version]; !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownCodecVersion, version)
+	}
+	txVersions[reflect.TypeOf(utx)] = version
+	return nil
+}
+
+// SetDefaultVersion sets the codec version used to marshal txs
+// that have no version registered via "RegisterTxVersion".
+// Defaults to "LatestCodecVersion".
+func SetDefaultVersion(version uint16) error {
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+
+	if _, ok := versions[version]; !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownCodecVersion, version)
+	}
+	defaultVersion = version
+	return nil
+}
+
+// TxVersion returns the codec version to marshal [utx] with.
+func TxVersion(utx platformvm.UnsignedTx) uint16 {
+	versionsMu.RLock()
+	defer versionsMu.RUnlock()
+
+	if v, ok := txVersions[reflect.TypeOf(utx)]; ok {
+		return v
+	}
+	return defaultVersion
+}
+
+// MarshalUnsignedTx marshals [utx] with its codec version
+// (ref. "TxVersion"), as signed by the credentials.
+func MarshalUnsignedTx(utx platformvm.UnsignedTx) ([]byte, error) {
+	return PCodecManager.Marshal(TxVersion(utx), &utx)
+}
+
+// MarshalTx marshals the signed [tx] with the codec version of its
+// unsigned tx (ref. "TxVersion").
+func MarshalTx(tx *platformvm.Tx) ([]byte, error) {
+	return PCodecManager.Marshal(TxVersion(tx.UnsignedTx), tx)
+}
+
+// DecodeTx unmarshals the signed tx bytes and returns the initialized
+// tx with the codec version it was encoded with.
+func DecodeTx(b []byte) (*platformvm.Tx, uint16, error) {
+	tx := new(platformvm.Tx)
+	version, err := PCodecManager.Unmarshal(b, tx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal tx bytes: %w", err)
+	}
+	unsignedBytes, err := PCodecManager.Marshal(version, &tx.UnsignedTx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
 	}
+	tx.Initialize(unsignedBytes, b)
+	return tx, version, nil
 }
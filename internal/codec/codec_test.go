@@ -0,0 +1,192 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/components/verify"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+)
+
+// testCodecVersion is registered once by "registerTestVersion", since
+// "PCodecManager" can't unregister a codec version.
+const testCodecVersion uint16 = 1
+
+var registerTestVersionOnce sync.Once
+
+func registerTestVersion(t *testing.T) {
+	registerTestVersionOnce.Do(func() {
+		pc, err := NewCodec()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := RegisterCodecVersion(testCodecVersion, pc); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func testTxs() []struct {
+	name string
+	utx  platformvm.UnsignedTx
+} {
+	baseTx := platformvm.BaseTx{BaseTx: djtx.BaseTx{
+		NetworkID:    12345,
+		BlockchainID: ids.Empty,
+		Ins: []*djtx.TransferableInput{{
+			UTXOID: djtx.UTXOID{TxID: ids.ID{1}},
+			Asset:  djtx.Asset{ID: ids.ID{2}},
+			In:     &secp256k1fx.TransferInput{Amt: 1000, Input: secp256k1fx.Input{SigIndices: []uint32{0}}},
+		}},
+	}}
+	owner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{3}}}
+	auth := &secp256k1fx.Input{SigIndices: []uint32{0}}
+	return []struct {
+		name string
+		utx  platformvm.UnsignedTx
+	}{
+		{name: "create subnet", utx: &platformvm.UnsignedCreateSubnetTx{BaseTx: baseTx, Owner: owner}},
+		{
+			name: "add subnet validator",
+			utx: &platformvm.UnsignedAddSubnetValidatorTx{
+				BaseTx: baseTx,
+				Validator: platformvm.SubnetValidator{
+					Validator: platformvm.Validator{NodeID: ids.ShortID{4}, Start: 1, End: 2, Wght: 1000},
+					Subnet:    ids.ID{5},
+				},
+				SubnetAuth: auth,
+			},
+		},
+		{
+			name: "create chain",
+			utx: &platformvm.UnsignedCreateChainTx{
+				BaseTx:      baseTx,
+				SubnetID:    ids.ID{5},
+				ChainName:   "test",
+				VMID:        ids.ID{6},
+				GenesisData: []byte("genesis"),
+				SubnetAuth:  auth,
+			},
+		},
+	}
+}
+
+func TestDecodeTxRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for i, tv := range testTxs() {
+		tx := &platformvm.Tx{
+			UnsignedTx: tv.utx,
+			Creds:      []verify.Verifiable{&secp256k1fx.Credential{Sigs: make([][crypto.SECP256K1RSigLen]byte, 1)}},
+		}
+		b, err := MarshalTx(tx)
+		if err != nil {
+			t.Fatalf("#%d(%s): %v", i, tv.name, err)
+		}
+		decoded, version, err := DecodeTx(b)
+		if err != nil {
+			t.Fatalf("#%d(%s): %v", i, tv.name, err)
+		}
+		if version != LatestCodecVersion {
+			t.Fatalf("#%d(%s): unexpected codec version %d, expected %d", i, tv.name, version, LatestCodecVersion)
+		}
+		if !bytes.Equal(decoded.Bytes(), b) {
+			t.Fatalf("#%d(%s): unexpected decoded bytes", i, tv.name)
+		}
+		unsignedBytes, err := MarshalUnsignedTx(tv.utx)
+		if err != nil {
+			t.Fatalf("#%d(%s): %v", i, tv.name, err)
+		}
+		if !bytes.Equal(decoded.UnsignedBytes(), unsignedBytes) {
+			t.Fatalf("#%d(%s): unexpected decoded unsigned bytes", i, tv.name)
+		}
+		if len(decoded.Creds) != 1 {
+			t.Fatalf("#%d(%s): unexpected %d credentials, expected 1", i, tv.name, len(decoded.Creds))
+		}
+	}
+}
+
+func TestDecodeTxInvalid(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		b    []byte
+	}{
+		{name: "empty", b: nil},
+		{name: "unknown codec version", b: []byte{0xff, 0xff, 0, 0, 0, 0}},
+		{name: "truncated", b: []byte{0, 0, 0, 0}},
+	}
+	for i, tv := range tt {
+		if _, _, err := DecodeTx(tv.b); err == nil {
+			t.Fatalf("#%d(%s): expected error", i, tv.name)
+		}
+	}
+}
+
+func TestUnknownCodecVersion(t *testing.T) {
+	t.Parallel()
+
+	if err := RegisterTxVersion(&platformvm.UnsignedCreateSubnetTx{}, 99); !errors.Is(err, ErrUnknownCodecVersion) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrUnknownCodecVersion)
+	}
+	if err := SetDefaultVersion(99); !errors.Is(err, ErrUnknownCodecVersion) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrUnknownCodecVersion)
+	}
+	if v := TxVersion(&platformvm.UnsignedCreateSubnetTx{}); v != LatestCodecVersion {
+		t.Fatalf("unexpected codec version %d, expected %d", v, LatestCodecVersion)
+	}
+}
+
+// Not parallel, since it changes the codec version of "UnsignedImportTx".
+func TestRegisterTxVersion(t *testing.T) {
+	registerTestVersion(t)
+	if err := RegisterCodecVersion(testCodecVersion, nil); err == nil {
+		t.Fatal("expected error registering a duplicate codec version")
+	}
+
+	utx := &platformvm.UnsignedImportTx{
+		BaseTx:      platformvm.BaseTx{BaseTx: djtx.BaseTx{NetworkID: 12345}},
+		SourceChain: ids.ID{7},
+	}
+	if err := RegisterTxVersion(utx, testCodecVersion); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		versionsMu.Lock()
+		delete(txVersions, reflect.TypeOf(utx))
+		versionsMu.Unlock()
+	})
+
+	tt := []struct {
+		name    string
+		utx     platformvm.UnsignedTx
+		version uint16
+	}{
+		{name: "registered tx type", utx: utx, version: testCodecVersion},
+		{name: "default tx type", utx: &platformvm.UnsignedExportTx{DestinationChain: ids.ID{7}}, version: LatestCodecVersion},
+	}
+	for i, tv := range tt {
+		b, err := MarshalTx(&platformvm.Tx{UnsignedTx: tv.utx})
+		if err != nil {
+			t.Fatalf("#%d(%s): %v", i, tv.name, err)
+		}
+		_, version, err := DecodeTx(b)
+		if err != nil {
+			t.Fatalf("#%d(%s): %v", i, tv.name, err)
+		}
+		if version != tv.version {
+			t.Fatalf("#%d(%s): unexpected codec version %d, expected %d", i, tv.name, version, tv.version)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+)
+
+var (
+	ErrGenesisTooLarge     = errors.New("genesis too large")
+	ErrInsecureGenesisURL  = errors.New("insecure genesis URL")
+	ErrGenesisFetchFailed  = errors.New("genesis fetch failed")
+	ErrInvalidGenesisMedia = errors.New("invalid genesis content type")
+)
+
+const (
+	// MaxTxSize is the maximum size of a tx accepted by the node.
+	MaxTxSize = 64 * units.KiB
+	// MaxSize is the largest genesis that may fit in a blockchain, as the
+	// genesis is embedded in the create blockchain tx. A genesis close to
+	// the limit may still be rejected once the tx inputs are added.
+	MaxSize = MaxTxSize
+
+	fetchTimeout = time.Minute
+	maxRedirects = 10
+)
+
+// content types a genesis may be served with; anything else (e.g., an HTML
+// login or error page) is rejected rather than committed on-chain
+var genesisMediaTypes = map[string]struct{}{
+	"application/json":         {},
+	"application/octet-stream": {},
+	"text/plain":               {},
+}
+
+// httpClient never follows a redirect off "https://", and bounds each
+// fetch even if [ctx] doesn't.
+var httpClient = &http.Client{
+	Timeout:       fetchTimeout,
+	CheckRedirect: checkRedirect,
+}
+
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("%w: redirected to %q", ErrInsecureGenesisURL, req.URL)
+	}
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return nil
+}
+
+// Load reads the VM genesis from the file at [src], or fetches it if
+// [src] is an "https://" URL (e.g., a canonical genesis in an artifact
+// store). Either way, it fails with "ErrGenesisTooLarge" if the genesis
+// exceeds "MaxSize". The fetch is bounded by [ctx], and fails with
+// "ErrInsecureGenesisURL" if redirected off "https://".
+func Load(ctx context.Context, src string) ([]byte, error) {
+	return load(ctx, httpClient, src)
+}
+
+func load(ctx context.Context, cli *http.Client, src string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(src, "https://"):
+		return fetch(ctx, cli, src)
+	case strings.HasPrefix(src, "http://"):
+		return nil, fmt.Errorf("%w: %q (use https://)", ErrInsecureGenesisURL, src)
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readLimited(f, src)
+}
+
+func fetch(ctx context.Context, cli *http.Client, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.Do(req)
+	if errors.Is(err, ErrInsecureGenesisURL) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGenesisFetchFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %q returned status %d", ErrGenesisFetchFailed, u, resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q (%v)", ErrInvalidGenesisMedia, ct, err)
+		}
+		if _, ok := genesisMediaTypes[mt]; !ok {
+			return nil, fmt.Errorf("%w: %q served %q", ErrInvalidGenesisMedia, u, mt)
+		}
+	}
+	if resp.ContentLength > MaxSize {
+		return nil, fmt.Errorf("%w: %q is %d bytes (limit %d bytes)", ErrGenesisTooLarge, u, resp.ContentLength, MaxSize)
+	}
+	b, err := readLimited(resp.Body, u)
+	if err != nil && !errors.Is(err, ErrGenesisTooLarge) {
+		return nil, fmt.Errorf("%w: %v", ErrGenesisFetchFailed, err)
+	}
+	return b, err
+}
+
+// readLimited reads [r] up to "MaxSize", failing if there's more.
+func readLimited(r io.Reader, src string) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(r, MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > MaxSize {
+		return nil, fmt.Errorf("%w: %q exceeds %d bytes", ErrGenesisTooLarge, src, MaxSize)
+	}
+	return b, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	genesis := []byte(`{"config":{"chainId":1}}`)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/genesis.json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(genesis)
+		case "/login":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html></html>"))
+		case "/redirect":
+			http.Redirect(w, r, "http://"+r.Host+"/genesis.json", http.StatusFound)
+		case "/large":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(make([]byte, MaxSize+1))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	genesisPath := filepath.Join(dir, "genesis.json")
+	if err := os.WriteFile(genesisPath, genesis, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	largePath := filepath.Join(dir, "large.json")
+	if err := os.WriteFile(largePath, make([]byte, MaxSize+1), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tt := []struct {
+		src      string
+		expected []byte
+		err      error
+	}{
+		{src: genesisPath, expected: genesis},
+		{src: largePath, err: ErrGenesisTooLarge},
+		{src: srv.URL + "/genesis.json", expected: genesis},
+		{src: srv.URL + "/login", err: ErrInvalidGenesisMedia},
+		{src: srv.URL + "/large", err: ErrGenesisTooLarge},
+		{src: srv.URL + "/missing", err: ErrGenesisFetchFailed},
+		{src: srv.URL + "/redirect", err: ErrInsecureGenesisURL},
+		{src: "http://localhost/genesis.json", err: ErrInsecureGenesisURL},
+	}
+	cli := srv.Client()
+	cli.CheckRedirect = checkRedirect
+	for i, tv := range tt {
+		b, err := load(context.Background(), cli, tv.src)
+		if !errors.Is(err, tv.err) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.src, err, tv.err)
+		}
+		if !bytes.Equal(b, tv.expected) {
+			t.Fatalf("#%d(%s): unexpected genesis %q, expected %q", i, tv.src, b, tv.expected)
+		}
+	}
+}
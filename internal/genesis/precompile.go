@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package genesis implements VM genesis helpers.
+package genesis
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	ErrInvalidPrecompileConfig = errors.New("invalid precompile config")
+	ErrInvalidTimestamp        = errors.New("invalid activation timestamp")
+	ErrInvalidAddress          = errors.New("invalid address")
+	ErrDuplicateAddress        = errors.New("duplicate address")
+)
+
+// subnet-evm stateful precompile configs in the genesis "config" section
+// ref. https://github.com/ava-labs/subnet-evm/blob/master/params/precompile_config.go
+var precompileConfigKeys = []string{
+	"contractDeployerAllowListConfig",
+	"contractNativeMinterConfig",
+	"txAllowListConfig",
+	"feeManagerConfig",
+}
+
+// allow list role fields, each a list of hex addresses
+var precompileAddressKeys = []string{
+	"adminAddresses",
+	"enabledAddresses",
+}
+
+// ValidatePrecompiles validates the precompile configuration in the
+// subnet-evm [genesis] (allow list addresses, activation timestamps),
+// and returns an error naming the offending field.
+// It is a no-op for genesis that is not a subnet-evm JSON genesis.
+func ValidatePrecompiles(genesis []byte) error {
+	var g struct {
+		Config map[string]json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(genesis, &g); err != nil {
+		// not a JSON genesis, so not subnet-evm
+		return nil //nolint:nilerr
+	}
+	for _, k := range precompileConfigKeys {
+		raw, ok := g.Config[k]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+		if err := validatePrecompile("config."+k, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePrecompile(field string, raw json.RawMessage) error {
+	var cfg map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidPrecompileConfig, field, err)
+	}
+
+	if ts, ok := cfg["blockTimestamp"]; ok {
+		var v float64
+		if err := json.Unmarshal(ts, &v); err != nil {
+			return fmt.Errorf("%w: %s.blockTimestamp: %s", ErrInvalidTimestamp, field, ts)
+		}
+		if v < 0 || v != math.Trunc(v) {
+			return fmt.Errorf("%w: %s.blockTimestamp: %s (expected non-negative integer)", ErrInvalidTimestamp, field, ts)
+		}
+	}
+
+	seen := make(map[string]string)
+	for _, k := range precompileAddressKeys {
+		raw, ok := cfg[k]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+		var addrs []string
+		if err := json.Unmarshal(raw, &addrs); err != nil {
+			return fmt.Errorf("%w: %s.%s: %v", ErrInvalidPrecompileConfig, field, k, err)
+		}
+		for i, addr := range addrs {
+			addrField := fmt.Sprintf("%s.%s[%d]", field, k, i)
+			if !isHexAddress(addr) {
+				return fmt.Errorf("%w: %s: %q", ErrInvalidAddress, addrField, addr)
+			}
+			// an address can only hold one role
+			norm := strings.ToLower(addr)
+			if prev, ok := seen[norm]; ok {
+				return fmt.Errorf("%w: %s: %q (already in %s)", ErrDuplicateAddress, addrField, addr, prev)
+			}
+			seen[norm] = addrField
+		}
+	}
+	return nil
+}
+
+// isHexAddress returns true if [s] is a "0x"-prefixed 20-byte hex address.
+func isHexAddress(s string) bool {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return false
+	}
+	b, err := hex.DecodeString(s[2:])
+	return err == nil && len(b) == 20
+}
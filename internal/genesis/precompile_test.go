@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidatePrecompiles(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name    string
+		genesis string
+		expErr  error
+	}{
+		{
+			name:    "not json",
+			genesis: "my-custom-vm-genesis",
+			expErr:  nil,
+		},
+		{
+			name:    "no precompiles",
+			genesis: `{"config":{"chainId":99999}}`,
+			expErr:  nil,
+		},
+		{
+			name:    "valid allow list",
+			genesis: `{"config":{"txAllowListConfig":{"blockTimestamp":0,"adminAddresses":["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]}}}`,
+			expErr:  nil,
+		},
+		{
+			name:    "negative timestamp",
+			genesis: `{"config":{"txAllowListConfig":{"blockTimestamp":-1}}}`,
+			expErr:  ErrInvalidTimestamp,
+		},
+		{
+			name:    "non-integer timestamp",
+			genesis: `{"config":{"feeManagerConfig":{"blockTimestamp":"now"}}}`,
+			expErr:  ErrInvalidTimestamp,
+		},
+		{
+			name:    "short address",
+			genesis: `{"config":{"contractNativeMinterConfig":{"adminAddresses":["0x8db97C7c"]}}}`,
+			expErr:  ErrInvalidAddress,
+		},
+		{
+			name:    "missing 0x prefix",
+			genesis: `{"config":{"contractNativeMinterConfig":{"adminAddresses":["8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]}}}`,
+			expErr:  ErrInvalidAddress,
+		},
+		{
+			name:    "address in two roles",
+			genesis: `{"config":{"contractDeployerAllowListConfig":{"adminAddresses":["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"],"enabledAddresses":["0x8db97c7cece249c2b98bdc0226cc4c2a57bf52fc"]}}}`,
+			expErr:  ErrDuplicateAddress,
+		},
+		{
+			name:    "malformed config",
+			genesis: `{"config":{"txAllowListConfig":[]}}`,
+			expErr:  ErrInvalidPrecompileConfig,
+		},
+	}
+	for i, tv := range tt {
+		err := ValidatePrecompiles([]byte(tv.genesis))
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expErr)
+		}
+	}
+}
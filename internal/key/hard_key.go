@@ -4,9 +4,10 @@
 package key
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
-	"github.com/lasthyphen/subnet-cli/internal/codec"
 	"github.com/lasthyphen/subnet-cli/pkg/color"
 
 	ledger "github.com/lasthyphen/djiets-ledger-go"
@@ -24,11 +25,18 @@ import (
 var _ Key = &HardKey{}
 
 type HardKey struct {
-	l *ledger.Ledger
+	// guards [l], so that "Close" doesn't disconnect the device
+	// under a pending signature
+	mu sync.Mutex
+	l  *ledger.Ledger
+	// device requests in flight, which "Close" waits for
+	inflight sync.WaitGroup
 
+	hrp          string
 	accountIndex uint32
 	shortAddr    ids.ShortID
 	pAddr        string
+	pubKey       []byte
 }
 
 func NewHard(networkID uint32, accountIndex uint32) (*HardKey, error) {
@@ -43,6 +51,7 @@ func NewHard(networkID uint32, accountIndex uint32) (*HardKey, error) {
 
 	color.Outf("{{yellow}}deriving address from ledger...{{/}}\n")
 	hrp := getHRP(networkID)
+	k.hrp = hrp
 	k.accountIndex = accountIndex
 	_, k.shortAddr, err = k.l.Address(hrp, k.accountIndex, 0)
 	if err != nil {
@@ -60,7 +69,76 @@ func NewHard(networkID uint32, accountIndex uint32) (*HardKey, error) {
 }
 
 func (h *HardKey) Disconnect() error {
-	return h.l.Disconnect()
+	l := h.device()
+	if l == nil {
+		return ErrLedgerClosed
+	}
+	return l.Disconnect()
+}
+
+// device returns the device connection, or nil once closed.
+func (h *HardKey) device() *ledger.Ledger {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.l
+}
+
+// Close disconnects the Ledger device, so the app doesn't get stuck
+// between runs. It is safe to call more than once. New signatures fail
+// with "ErrLedgerClosed" right away, but a pending one (e.g., cancelled
+// while waiting for the confirmation) is waited for until it is
+// confirmed or rejected on the device.
+func (h *HardKey) Close() error {
+	h.mu.Lock()
+	l := h.l
+	h.l = nil
+	h.mu.Unlock()
+
+	h.inflight.Wait()
+	if l == nil {
+		return nil
+	}
+	return l.Disconnect()
+}
+
+// DeriveAddresses derives the addresses of the first [count] account
+// indexes over the same device connection, one request at a time.
+// If the derivation fails (e.g., rejected on the device), it returns
+// the addresses derived so far with the error.
+func (h *HardKey) DeriveAddresses(count uint32) (pAddrs []string, shortAddrs []ids.ShortID, err error) {
+	if h.device() == nil {
+		return nil, nil, ErrLedgerClosed
+	}
+	pAddrs = make([]string, 0, count)
+	shortAddrs = make([]ids.ShortID, 0, count)
+	for i := uint32(0); i < count; i++ {
+		shortAddr, err := h.DeriveAddress(i)
+		if err != nil {
+			return pAddrs, shortAddrs, err
+		}
+		pAddr, err := formatting.FormatAddress("P", h.hrp, shortAddr[:])
+		if err != nil {
+			return pAddrs, shortAddrs, err
+		}
+		pAddrs = append(pAddrs, pAddr)
+		shortAddrs = append(shortAddrs, shortAddr)
+	}
+	return pAddrs, shortAddrs, nil
+}
+
+// DeriveAddress derives the address of the account index [idx] over the
+// same device connection, without switching the signing account.
+func (h *HardKey) DeriveAddress(idx uint32) (ids.ShortID, error) {
+	l := h.device()
+	if l == nil {
+		return ids.ShortEmpty, ErrLedgerClosed
+	}
+	_, shortAddr, err := l.Address(h.hrp, idx, 0)
+	if err != nil {
+		color.Outf("{{yellow}}failed to derive address (idx=%d): %v{{/}}\n", idx, err)
+		return ids.ShortEmpty, fmt.Errorf("failed to derive address %d: %w", idx, err)
+	}
+	return shortAddr, nil
 }
 
 func (h *HardKey) P() string { return h.pAddr }
@@ -69,6 +147,39 @@ func (h *HardKey) Address() ids.ShortID {
 	return h.shortAddr
 }
 
+// pubKeyMessage is signed on the device to recover the public key, since
+// the device only exposes addresses.
+var pubKeyMessage = []byte("subnet-cli: export public key")
+
+// PublicKey asks the device to sign a fixed message, and recovers the public
+// key from the signature. It requires a confirmation on the device the first
+// time, and is cached afterwards.
+func (h *HardKey) PublicKey() ([]byte, error) {
+	if h.pubKey != nil {
+		return h.pubKey, nil
+	}
+	l := h.device()
+	if l == nil {
+		return nil, ErrLedgerClosed
+	}
+	hash := hashing.ComputeHash256(pubKeyMessage)
+	color.Outf("{{yellow}}confirm the signature on the ledger to export the public key...{{/}}\n")
+	sigs, err := l.SignHash(hash, [][]uint32{{0, h.accountIndex}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign for public key: %w", err)
+	}
+	factory := &crypto.FactorySECP256K1R{}
+	pk, err := factory.RecoverHashPublicKey(hash, sigs[0])
+	if err != nil {
+		return nil, err
+	}
+	if pk.Address() != h.shortAddr {
+		return nil, fmt.Errorf("%w: recovered %s, expected %s", ErrPublicKeyMismatch, pk.Address(), h.shortAddr)
+	}
+	h.pubKey = pk.Bytes()
+	return h.pubKey, nil
+}
+
 func (h *HardKey) Spends(outputs []*djtx.UTXO, opts ...OpOption) (
 	totalBalanceToSpend uint64,
 	inputs []*djtx.TransferableInput,
@@ -154,35 +265,71 @@ func (h *HardKey) match(owners *secp256k1fx.OutputOwners, time uint64) ([]uint32
 	return sigs, uint32(len(sigs)) == owners.Threshold
 }
 
-// Sign transaction with the ledger private key
-//
-// This is a slightly modified version of *platformvm.Tx.Sign().
-func (h *HardKey) Sign(pTx *platformvm.Tx, sigs int) error {
-	unsignedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, &pTx.UnsignedTx)
-	if err != nil {
-		return fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+// signHash waits for the device to sign [hash], or for [ctx] to be done.
+// The device request itself can't be aborted, so on cancellation it is
+// left pending and the key must be closed.
+func (h *HardKey) signHash(ctx context.Context, hash []byte) ([][]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSigningCancelled, err)
 	}
+	h.mu.Lock()
+	l := h.l
+	if l == nil {
+		h.mu.Unlock()
+		return nil, ErrLedgerClosed
+	}
+	h.inflight.Add(1)
+	h.mu.Unlock()
 
-	// Generate signature
-	hash := hashing.ComputeHash256(unsignedBytes)
-	cred := &secp256k1fx.Credential{
-		Sigs: make([][crypto.SECP256K1RSigLen]byte, 1),
+	type result struct {
+		sig [][]byte
+		err error
 	}
-	sig, err := h.l.SignHash(hash, [][]uint32{{0, h.accountIndex}})
-	if err != nil {
-		return fmt.Errorf("problem generating credential: %w", err)
+	// buffered, so the request doesn't leak once cancelled
+	ch := make(chan result, 1)
+	color.Outf("{{yellow}}confirm the signature on the ledger (press Ctrl+C to cancel)...{{/}}\n")
+	go func() {
+		defer h.inflight.Done()
+		sig, err := l.SignHash(hash, [][]uint32{{0, h.accountIndex}})
+		ch <- result{sig: sig, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: %v", ErrSigningCancelled, ctx.Err())
+	case r := <-ch:
+		if r.err != nil {
+			return nil, fmt.Errorf("problem generating credential: %w", r.err)
+		}
+		return r.sig, nil
 	}
+}
 
-	// Copy signature required times
-	copy(cred.Sigs[0][:], sig[0])
-	for i := 0; i < sigs; i++ {
-		pTx.Creds = append(pTx.Creds, cred) // Attach credential
+// Sign transaction with the ledger private key
+//
+// This is a slightly modified version of *platformvm.Tx.Sign(),
+// marshaling with the codec version registered for the tx type.
+func (h *HardKey) Sign(ctx context.Context, pTx *platformvm.Tx, sigs int) error {
+	hash, unsignedBytes, err := SigningHash(pTx.UnsignedTx)
+	if err != nil {
+		return err
 	}
 
-	signedBytes, err := codec.PCodecManager.Marshal(platformvm.CodecVersion, pTx)
+	// all the inputs are of the one address, so the device is
+	// asked to confirm a single signature
+	return signDeduped(pTx, unsignedBytes, singleSigner(h.Address(), sigs), func(signer ids.ShortID) ([]byte, error) {
+		return h.SignHash(ctx, signer, hash)
+	})
+}
+
+// SignHash asks the device to sign [hash] with the key of the account
+// index, which is the only address [addr] it can sign for.
+func (h *HardKey) SignHash(ctx context.Context, addr ids.ShortID, hash []byte) ([]byte, error) {
+	if addr != h.shortAddr {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSigner, addr)
+	}
+	sig, err := h.signHash(ctx, hash)
 	if err != nil {
-		return fmt.Errorf("couldn't marshal ProposalTx: %w", err)
+		return nil, err
 	}
-	pTx.Initialize(unsignedBytes, signedBytes)
-	return nil
+	return sig[0], nil
 }
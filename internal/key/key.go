@@ -5,17 +5,28 @@
 package key
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/utils/constants"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
 	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
 	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+
+	"github.com/lasthyphen/subnet-cli/internal/codec"
 )
 
 var (
-	ErrInvalidType = errors.New("invalid type")
-	ErrCantSpend   = errors.New("can't spend")
+	ErrInvalidType  = errors.New("invalid type")
+	ErrCantSpend    = errors.New("can't spend")
+	ErrLedgerClosed = errors.New("ledger closed")
+
+	ErrSigningCancelled  = errors.New("signing cancelled")
+	ErrPublicKeyMismatch = errors.New("public key does not match address")
 )
 
 // Key defines methods for key manager interface.
@@ -24,6 +35,9 @@ type Key interface {
 	P() string
 	// Address returns the raw ids.ShortID address.
 	Address() ids.ShortID
+	// PublicKey returns the compressed secp256k1 public key, for
+	// watch-only setups or to verify signatures made elsewhere.
+	PublicKey() ([]byte, error)
 	// Spend attempts to spend all specified UTXOs (outputs)
 	// and returns the new UTXO inputs.
 	//
@@ -35,7 +49,114 @@ type Key interface {
 		inputs []*djtx.TransferableInput,
 	)
 	// Sign generates [numSigs] signatures and attaches them to [pTx].
-	Sign(pTx *platformvm.Tx, numSigs int) error
+	// It fails with "ErrSigningCancelled" once [ctx] is done, even if
+	// the signature is pending on the device.
+	Sign(ctx context.Context, pTx *platformvm.Tx, numSigs int) error
+	// SignHash signs [hash] (ref. "SigningHash") with the private key of
+	// [addr], for the credentials that only some of the signers sign
+	// (ref. "SignPartial"). It fails with "ErrUnknownSigner" if the key
+	// doesn't hold [addr].
+	SignHash(ctx context.Context, addr ids.ShortID, hash []byte) ([]byte, error)
+	// Close releases the resources held by the key (e.g., disconnects
+	// the Ledger device). It must be called once the key is no longer used.
+	Close() error
+}
+
+// MultiKey is a "Key" of several private keys (ref. "NewSoftMulti"),
+// any of which may sign the subnet auth.
+type MultiKey interface {
+	Key
+	// Match returns true if any of the keys has [addr].
+	Match(addr ids.ShortID) bool
+	// SetAuthSigner sets the key of [addr] to sign the subnet auth.
+	// It fails with "ErrUnknownSigner" if no key has [addr].
+	SetAuthSigner(addr ids.ShortID) error
+}
+
+// SigningHash returns the 32-byte hash of the unsigned tx that each
+// credential signs, with the marshaled unsigned tx bytes. External
+// signers (e.g., HSMs) sign this hash to produce the credentials.
+func SigningHash(utx platformvm.UnsignedTx) (hash []byte, unsignedBytes []byte, err error) {
+	unsignedBytes, err = codec.MarshalUnsignedTx(utx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+	}
+	return hashing.ComputeHash256(unsignedBytes), unsignedBytes, nil
+}
+
+// DedupSigners returns the unique signers of the credentials, in the
+// order they first sign, and for each credential the indices of its
+// signers in [unique]. [perCred] lists the signers of each credential
+// (e.g., the owners of the UTXO each input spends).
+//
+// Signing the hash once per unique signer, and copying the signatures
+// into each credential, keeps the per-input credentials intact with as
+// few signatures as there are distinct signers. e.g., a 20-input tx from
+// one owner takes 1 Ledger confirmation, not 20.
+func DedupSigners(perCred [][]ids.ShortID) (unique []ids.ShortID, indices [][]int) {
+	pos := make(map[ids.ShortID]int)
+	indices = make([][]int, len(perCred))
+	for i, signers := range perCred {
+		indices[i] = make([]int, len(signers))
+		for j, signer := range signers {
+			k, ok := pos[signer]
+			if !ok {
+				k = len(unique)
+				pos[signer] = k
+				unique = append(unique, signer)
+			}
+			indices[i][j] = k
+		}
+	}
+	return unique, indices
+}
+
+// signDeduped attaches a credential per entry of [perCred] to [pTx],
+// calling [signHash] once per unique signer (ref. "DedupSigners").
+func signDeduped(
+	pTx *platformvm.Tx,
+	unsignedBytes []byte,
+	perCred [][]ids.ShortID,
+	signHash func(signer ids.ShortID) ([]byte, error),
+) error {
+	unique, indices := DedupSigners(perCred)
+	sigs := make([][crypto.SECP256K1RSigLen]byte, len(unique))
+	for i, signer := range unique {
+		sig, err := signHash(signer)
+		if err != nil {
+			return err
+		}
+		copy(sigs[i][:], sig)
+	}
+	for _, idx := range indices {
+		cred := &secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(idx)),
+		}
+		for j, k := range idx {
+			cred.Sigs[j] = sigs[k]
+		}
+		pTx.Creds = append(pTx.Creds, cred) // Attach credential
+	}
+	return initializeSigned(pTx, unsignedBytes)
+}
+
+// singleSigner returns [numSigs] credentials signed by [addr] alone.
+func singleSigner(addr ids.ShortID, numSigs int) [][]ids.ShortID {
+	perCred := make([][]ids.ShortID, numSigs)
+	for i := range perCred {
+		perCred[i] = []ids.ShortID{addr}
+	}
+	return perCred
+}
+
+// initializeSigned sets the bytes of [pTx] once its credentials are attached.
+func initializeSigned(pTx *platformvm.Tx, unsignedBytes []byte) error {
+	signedBytes, err := codec.MarshalTx(pTx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal ProposalTx: %w", err)
+	}
+	pTx.Initialize(unsignedBytes, signedBytes)
+	return nil
 }
 
 type Op struct {
@@ -5,17 +5,28 @@ package key
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
 	"github.com/lasthyphen/dijetsnodego/utils/formatting"
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+	ledger "github.com/lasthyphen/djiets-ledger-go"
+
+	"github.com/lasthyphen/subnet-cli/internal/codec"
 )
 
 const (
 	ewoqPChainAddr    = "P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p"
 	fallbackNetworkID = 999999 // unaffiliated networkID should trigger HRP Fallback
+	ewoqHexPk         = "56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027"
 )
 
 func TestNewKeyEwoq(t *testing.T) {
@@ -32,6 +43,22 @@ func TestNewKeyEwoq(t *testing.T) {
 	if m.P() != ewoqPChainAddr {
 		t.Fatalf("unexpected P-Chain address %q, expected %q", m.P(), ewoqPChainAddr)
 	}
+	hexKey, cb58Key, addr := m.Backup()
+	if hexKey != ewoqHexPk || cb58Key != EwoqPrivateKey || addr != ewoqPChainAddr {
+		t.Fatalf("unexpected backup (%q, %q, %q)", hexKey, cb58Key, addr)
+	}
+
+	pubKey, err := m.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := (&crypto.FactorySECP256K1R{}).ToPublicKey(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubKey) != 33 || pk.Address() != m.Address() {
+		t.Fatalf("unexpected public key %x for %s", pubKey, m.Address())
+	}
 
 	keyPath := filepath.Join(t.TempDir(), "key.pk")
 	if err := m.Save(keyPath); err != nil {
@@ -116,3 +143,425 @@ func TestNewKey(t *testing.T) {
 		}
 	}
 }
+
+func TestDecodePrivateKey(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		enc    string
+		expErr error
+	}{
+		{name: "cb58", enc: EwoqPrivateKey},
+		{name: "hex", enc: ewoqHexPk},
+		{name: "0x hex", enc: "0x" + ewoqHexPk},
+		{name: "0x hex with newline", enc: "0x" + ewoqHexPk + "\n"},
+		{name: "short 0x hex", enc: "0x" + ewoqHexPk[2:], expErr: ErrInvalidPrivateKeyLen},
+		{name: "invalid hex", enc: "zz" + ewoqHexPk[2:], expErr: ErrInvalidPrivateKeyEncoding},
+		{name: "invalid cb58", enc: privKeyEncPfx + "invalid", expErr: ErrInvalidPrivateKeyEncoding},
+	}
+	for i, tv := range tt {
+		k, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(tv.enc))
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expErr)
+		}
+		if err == nil && k.Encode() != EwoqPrivateKey {
+			t.Fatalf("#%d(%s): unexpected key %q, expected %q", i, tv.name, k.Encode(), EwoqPrivateKey)
+		}
+	}
+}
+
+func TestSignDedup(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pTx := &platformvm.Tx{
+		UnsignedTx: &platformvm.UnsignedCreateSubnetTx{
+			Owner: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{m.Address()},
+			},
+		},
+	}
+	numSigs := 20
+	if err := m.Sign(context.Background(), pTx, numSigs); err != nil {
+		t.Fatal(err)
+	}
+	if len(pTx.Creds) != numSigs {
+		t.Fatalf("unexpected %d credentials, expected %d", len(pTx.Creds), numSigs)
+	}
+
+	// every input credential carries the one signature of the owner
+	hash, _, err := SigningHash(pTx.UnsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	factory := &crypto.FactorySECP256K1R{}
+	for i, c := range pTx.Creds {
+		cred, ok := c.(*secp256k1fx.Credential)
+		if !ok || len(cred.Sigs) != 1 {
+			t.Fatalf("#%d: unexpected credential %+v", i, c)
+		}
+		pk, err := factory.RecoverHashPublicKey(hash, cred.Sigs[0][:])
+		if err != nil {
+			t.Fatalf("#%d: unexpected error %v", i, err)
+		}
+		if pk.Address() != m.Address() {
+			t.Fatalf("#%d: unexpected signer %s, expected %s", i, pk.Address(), m.Address())
+		}
+	}
+}
+
+func TestDedupSigners(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := ids.ShortID{1}, ids.ShortID{2}, ids.ShortID{3}
+	many := make([][]ids.ShortID, 20)
+	for i := range many {
+		many[i] = []ids.ShortID{a}
+	}
+	tt := []struct {
+		name            string
+		perCred         [][]ids.ShortID
+		expectedUnique  []ids.ShortID
+		expectedIndices [][]int
+	}{
+		{name: "no inputs", perCred: [][]ids.ShortID{}, expectedIndices: [][]int{}},
+		{name: "one owner", perCred: [][]ids.ShortID{{a}, {a}, {a}}, expectedUnique: []ids.ShortID{a}, expectedIndices: [][]int{{0}, {0}, {0}}},
+		{name: "two owners", perCred: [][]ids.ShortID{{b}, {a}, {b}}, expectedUnique: []ids.ShortID{b, a}, expectedIndices: [][]int{{0}, {1}, {0}}},
+		{name: "multisig input", perCred: [][]ids.ShortID{{a, c}, {c}, {b}}, expectedUnique: []ids.ShortID{a, c, b}, expectedIndices: [][]int{{0, 1}, {1}, {2}}},
+	}
+	for i, tv := range tt {
+		unique, indices := DedupSigners(tv.perCred)
+		if !reflect.DeepEqual(unique, tv.expectedUnique) {
+			t.Fatalf("#%d(%s): unexpected signers %v, expected %v", i, tv.name, unique, tv.expectedUnique)
+		}
+		if !reflect.DeepEqual(indices, tv.expectedIndices) {
+			t.Fatalf("#%d(%s): unexpected indices %v, expected %v", i, tv.name, indices, tv.expectedIndices)
+		}
+	}
+
+	// a 20-input tx from one owner signs once (e.g., 1 Ledger confirmation)
+	calls := 0
+	pTx := &platformvm.Tx{UnsignedTx: &platformvm.UnsignedCreateSubnetTx{
+		Owner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{a}},
+	}}
+	err := signDeduped(pTx, nil, many, func(ids.ShortID) ([]byte, error) {
+		calls++
+		return make([]byte, crypto.SECP256K1RSigLen), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 || len(pTx.Creds) != len(many) {
+		t.Fatalf("unexpected %d signatures for %d credentials, expected 1 for %d", calls, len(pTx.Creds), len(many))
+	}
+}
+
+func TestSignPartial(t *testing.T) {
+	t.Parallel()
+
+	keys := make([]*SoftKey, 3)
+	for i := range keys {
+		k, err := NewSoft(fallbackNetworkID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = k
+	}
+	a0, a1, a2 := keys[0].Address(), keys[1].Address(), keys[2].Address()
+
+	tt := []struct {
+		name       string
+		signers    [][]ids.ShortID
+		signs      []int
+		nonSigners []int
+		expLeft    []int
+	}{
+		{
+			name:       "2-of-3",
+			signers:    [][]ids.ShortID{{a0, a2}},
+			signs:      []int{2, 0},
+			nonSigners: []int{1},
+			expLeft:    []int{1, 0},
+		},
+		{
+			name:    "3-of-3 with 2 inputs",
+			signers: [][]ids.ShortID{{a0, a1, a2}, {a0, a1, a2}},
+			signs:   []int{0, 1, 2},
+			expLeft: []int{4, 2, 0},
+		},
+	}
+	for i, tv := range tt {
+		pTx := &platformvm.Tx{
+			UnsignedTx: &platformvm.UnsignedCreateSubnetTx{
+				Owner: &secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{a0},
+				},
+			},
+		}
+		for j, idx := range tv.signs {
+			status, err := SignPartial(context.Background(), pTx, keys[idx], tv.signers)
+			if err != nil {
+				t.Fatalf("#%d(%s): unexpected error %v", i, tv.name, err)
+			}
+			if status.Remaining != tv.expLeft[j] {
+				t.Fatalf("#%d(%s): unexpected %d remaining signatures, expected %d", i, tv.name, status.Remaining, tv.expLeft[j])
+			}
+			for _, a := range status.Missing {
+				if a == keys[idx].Address() {
+					t.Fatalf("#%d(%s): %s still missing after signing", i, tv.name, a)
+				}
+			}
+		}
+		for _, idx := range tv.nonSigners {
+			if _, err := SignPartial(context.Background(), pTx, keys[idx], tv.signers); !errors.Is(err, ErrNotSigner) {
+				t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, ErrNotSigner)
+			}
+		}
+
+		hash, _, err := SigningHash(pTx.UnsignedTx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		factory := &crypto.FactorySECP256K1R{}
+		for ci, c := range pTx.Creds {
+			cred := c.(*secp256k1fx.Credential)
+			for si, sig := range cred.Sigs {
+				pk, err := factory.RecoverHashPublicKey(hash, sig[:])
+				if err != nil {
+					t.Fatalf("#%d(%s): unexpected error %v", i, tv.name, err)
+				}
+				if pk.Address() != tv.signers[ci][si] {
+					t.Fatalf("#%d(%s): unexpected signer %s, expected %s", i, tv.name, pk.Address(), tv.signers[ci][si])
+				}
+			}
+		}
+	}
+}
+
+// A multi-key signs for each of its addresses of a 2-of-3 multisig, even
+// when its primary key doesn't sign.
+func TestSignPartialMultiKey(t *testing.T) {
+	t.Parallel()
+
+	keys := make([]*SoftKey, 4)
+	for i := range keys {
+		k, err := NewSoft(fallbackNetworkID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = k
+	}
+	a0, a1, a2 := keys[0].Address(), keys[1].Address(), keys[2].Address()
+	// primary key [keys[3]] isn't an owner
+	m, err := NewSoftMulti(fallbackNetworkID, [][]byte{keys[3].Raw(), keys[1].Raw(), keys[2].Raw()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2-of-3 owners [a0, a1, a2]: the subnet input signed by [a1, a2], the
+	// fee input by [a0, a1]
+	signers := [][]ids.ShortID{{a1, a2}, {a0, a1}}
+	pTx := &platformvm.Tx{
+		UnsignedTx: &platformvm.UnsignedCreateSubnetTx{
+			Owner: &secp256k1fx.OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{a0, a1, a2},
+			},
+		},
+	}
+	if _, err := SignPartial(context.Background(), pTx, keys[3], signers); !errors.Is(err, ErrNotSigner) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrNotSigner)
+	}
+	status, err := SignPartial(context.Background(), pTx, m, signers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Remaining != 1 || !reflect.DeepEqual(status.Missing, []ids.ShortID{a0}) {
+		t.Fatalf("unexpected status %+v, expected only %s missing", status, a0)
+	}
+	status, err = SignPartial(context.Background(), pTx, keys[0], signers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Complete() {
+		t.Fatalf("unexpected status %+v, expected complete", status)
+	}
+
+	hash, _, err := SigningHash(pTx.UnsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	factory := &crypto.FactorySECP256K1R{}
+	for ci, c := range pTx.Creds {
+		for si, sig := range c.(*secp256k1fx.Credential).Sigs {
+			pk, err := factory.RecoverHashPublicKey(hash, sig[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pk.Address() != signers[ci][si] {
+				t.Fatalf("credential %d: unexpected signer %s, expected %s", ci, pk.Address(), signers[ci][si])
+			}
+		}
+	}
+}
+
+func TestSignRawTx(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var utx platformvm.UnsignedTx = &platformvm.UnsignedAddSubnetValidatorTx{
+		SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+	unsignedTx := &platformvm.Tx{UnsignedTx: utx}
+	txBytes, err := codec.MarshalTx(unsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signedBytes, err := SignRawTx(context.Background(), txBytes, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pTx, _, err := codec.DecodeTx(signedBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// no inputs, so only the subnet auth credential
+	if len(pTx.Creds) != 1 {
+		t.Fatalf("unexpected %d credentials, expected 1", len(pTx.Creds))
+	}
+
+	if _, err := SignRawTx(context.Background(), []byte{0, 0}, m); err == nil {
+		t.Fatal("expected error for invalid tx bytes")
+	}
+}
+
+func TestNewSoftMulti(t *testing.T) {
+	t.Parallel()
+
+	k1, err := NewSoft(fallbackNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := NewSoft(fallbackNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSoftMulti(fallbackNetworkID, [][]byte{k1.Raw(), {1, 2}}); !errors.Is(err, ErrInvalidPrivateKey) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidPrivateKey)
+	}
+	m, err := NewSoftMulti(fallbackNetworkID, [][]byte{k1.Raw(), k2.Raw(), k1.Raw()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Address() != k1.Address() || m.P() != k1.P() {
+		t.Fatalf("unexpected primary address %s, expected %s", m.P(), k1.P())
+	}
+	if len(m.keyChain.Keys) != 2 {
+		t.Fatalf("unexpected %d keys, expected 2", len(m.keyChain.Keys))
+	}
+
+	// the UTXO of the second key is spent and signed by it
+	utxo := &djtx.UTXO{
+		UTXOID: djtx.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  djtx.Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1000,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{k2.Address()},
+			},
+		},
+	}
+	total, ins := m.Spends([]*djtx.UTXO{utxo})
+	if total != 1000 || len(ins) != 1 {
+		t.Fatalf("unexpected spend %d with %d inputs", total, len(ins))
+	}
+	utx := &platformvm.UnsignedCreateSubnetTx{
+		Owner: &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{m.Address()},
+		},
+	}
+	utx.Ins = ins
+	pTx := &platformvm.Tx{UnsignedTx: utx}
+	if err := m.Sign(context.Background(), pTx, len(ins)); err != nil {
+		t.Fatal(err)
+	}
+	hash, _, err := SigningHash(pTx.UnsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred, ok := pTx.Creds[0].(*secp256k1fx.Credential)
+	if !ok || len(cred.Sigs) != 1 {
+		t.Fatalf("unexpected credential %+v", pTx.Creds[0])
+	}
+	pk, err := (&crypto.FactorySECP256K1R{}).RecoverHashPublicKey(hash, cred.Sigs[0][:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk.Address() != k2.Address() {
+		t.Fatalf("unexpected signer %s, expected %s", pk.Address(), k2.Address())
+	}
+}
+
+func TestHardKeySignCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tt := []struct {
+		name        string
+		k           *HardKey
+		ctx         context.Context
+		expectedErr error
+	}{
+		// the device must not be asked to sign, so it is never used
+		{name: "cancelled", k: &HardKey{l: new(ledger.Ledger)}, ctx: ctx, expectedErr: ErrSigningCancelled},
+		{name: "closed and cancelled", k: &HardKey{}, ctx: ctx, expectedErr: ErrSigningCancelled},
+		{name: "closed", k: &HardKey{}, ctx: context.Background(), expectedErr: ErrLedgerClosed},
+	}
+	for i, tv := range tt {
+		pTx := &platformvm.Tx{
+			UnsignedTx: &platformvm.UnsignedAddSubnetValidatorTx{
+				SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}
+		err := tv.k.Sign(tv.ctx, pTx, 1)
+		if !errors.Is(err, tv.expectedErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expectedErr)
+		}
+		if len(pTx.Creds) != 0 {
+			t.Fatalf("#%d(%s): unexpected %d credentials", i, tv.name, len(pTx.Creds))
+		}
+	}
+}
+
+func TestHardKeyCloseWaitsForSign(t *testing.T) {
+	t.Parallel()
+
+	// a signature pending on the device
+	h := &HardKey{}
+	h.inflight.Add(1)
+	closed := make(chan error, 1)
+	go func() { closed <- h.Close() }()
+	select {
+	case err := <-closed:
+		t.Fatalf("closed with a pending signature (%v)", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	h.inflight.Done()
+	if err := <-closed; err != nil {
+		t.Fatal(err)
+	}
+}
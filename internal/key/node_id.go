@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/hashing"
+)
+
+var ErrInvalidCert = errors.New("invalid staking certificate")
+
+// NodeIDFromCert derives the node ID from the PEM-encoded staking
+// certificate of the node (e.g., "staker.crt").
+// ref. "node.Node.initNodeID".
+func NodeIDFromCert(certPEM []byte) (ids.ShortID, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return ids.ShortEmpty, fmt.Errorf("%w: no PEM certificate block", ErrInvalidCert)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("%w: %v", ErrInvalidCert, err)
+	}
+	return ids.ShortID(hashing.ComputeHash160Array(hashing.ComputeHash256(cert.Raw))), nil
+}
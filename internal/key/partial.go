@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/crypto"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	"github.com/lasthyphen/dijetsnodego/vms/secp256k1fx"
+
+	"github.com/lasthyphen/subnet-cli/internal/codec"
+)
+
+var (
+	ErrNotSigner          = errors.New("key is not a signer of the tx")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// SignatureStatus is the progress of collecting the signatures of a
+// multisig tx.
+type SignatureStatus struct {
+	// Remaining is the number of signatures still missing.
+	Remaining int
+	// Missing is the addresses whose signatures are still missing.
+	Missing []ids.ShortID
+}
+
+// Complete returns true if the tx has all its signatures.
+func (s *SignatureStatus) Complete() bool {
+	return s.Remaining == 0
+}
+
+// SignPartial adds the signatures of [k] to the M-of-N multisig [pTx],
+// and returns the signatures that other parties must still add.
+//
+// [signers] lists, for each credential (i.e., each input), the addresses
+// that must sign it, in the order of the input's signature indices.
+// The credentials are created on the first call, so the coordinator can
+// pass the same partially signed tx from one signer to the next.
+// Unlike "Key.Sign", it doesn't assume [k] alone satisfies the tx, and
+// signs for each of the addresses of [k] among [signers] (e.g., several
+// keys of "NewSoftMulti").
+func SignPartial(ctx context.Context, pTx *platformvm.Tx, k Key, signers [][]ids.ShortID) (*SignatureStatus, error) {
+	if len(pTx.Creds) == 0 {
+		for _, addrs := range signers {
+			pTx.Creds = append(pTx.Creds, &secp256k1fx.Credential{
+				Sigs: make([][crypto.SECP256K1RSigLen]byte, len(addrs)),
+			})
+		}
+	}
+	creds, err := credentials(pTx, signers)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, unsignedBytes, err := SigningHash(pTx.UnsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	// sign the hash once per address of [k] among the signers
+	sigs := make(map[ids.ShortID][crypto.SECP256K1RSigLen]byte)
+	tried := make(map[ids.ShortID]struct{})
+	for _, addrs := range signers {
+		for _, a := range addrs {
+			if _, ok := tried[a]; ok {
+				continue
+			}
+			tried[a] = struct{}{}
+			sig, err := k.SignHash(ctx, a, hash)
+			switch {
+			case errors.Is(err, ErrUnknownSigner):
+				// another party's address
+				continue
+			case err != nil:
+				return nil, err
+			}
+			var s [crypto.SECP256K1RSigLen]byte
+			copy(s[:], sig)
+			sigs[a] = s
+		}
+	}
+	if len(sigs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotSigner, k.Address())
+	}
+	for i, addrs := range signers {
+		for j, a := range addrs {
+			if sig, ok := sigs[a]; ok {
+				creds[i].Sigs[j] = sig
+			}
+		}
+	}
+
+	signedBytes, err := codec.MarshalTx(pTx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal tx: %w", err)
+	}
+	pTx.Initialize(unsignedBytes, signedBytes)
+
+	return signatureStatus(creds, signers), nil
+}
+
+// credentials returns the credentials of [pTx], checking they match
+// [signers].
+func credentials(pTx *platformvm.Tx, signers [][]ids.ShortID) ([]*secp256k1fx.Credential, error) {
+	if len(pTx.Creds) != len(signers) {
+		return nil, fmt.Errorf("%w: %d credentials for %d inputs", ErrInvalidCredentials, len(pTx.Creds), len(signers))
+	}
+	creds := make([]*secp256k1fx.Credential, len(pTx.Creds))
+	for i, c := range pTx.Creds {
+		cred, ok := c.(*secp256k1fx.Credential)
+		if !ok || len(cred.Sigs) != len(signers[i]) {
+			return nil, fmt.Errorf("%w: unexpected credential %d", ErrInvalidCredentials, i)
+		}
+		creds[i] = cred
+	}
+	return creds, nil
+}
+
+func signatureStatus(creds []*secp256k1fx.Credential, signers [][]ids.ShortID) *SignatureStatus {
+	status := &SignatureStatus{}
+	missing := make(map[ids.ShortID]struct{})
+	for i, cred := range creds {
+		for j, sig := range cred.Sigs {
+			if sig != [crypto.SECP256K1RSigLen]byte{} {
+				continue
+			}
+			status.Remaining++
+			a := signers[i][j]
+			if _, ok := missing[a]; !ok {
+				missing[a] = struct{}{}
+				status.Missing = append(status.Missing, a)
+			}
+		}
+	}
+	return status
+}
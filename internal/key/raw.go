@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lasthyphen/dijetsnodego/vms/components/djtx"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+
+	"github.com/lasthyphen/subnet-cli/internal/codec"
+)
+
+var ErrUnsupportedTx = errors.New("unsupported tx type")
+
+// SignRawTx decodes the P-Chain tx [txBytes], signs every one of its
+// credentials with [k] and returns the signed tx bytes. It supports the
+// txs built by other tools, as long as [k] alone satisfies them; any
+// credential already in [txBytes] is replaced.
+func SignRawTx(ctx context.Context, txBytes []byte, k Key) ([]byte, error) {
+	pTx, _, err := codec.DecodeTx(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	numSigs, err := numCredentials(pTx.UnsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	pTx.Creds = nil
+	if err := k.Sign(ctx, pTx, numSigs); err != nil {
+		return nil, err
+	}
+	return pTx.Bytes(), nil
+}
+
+// numCredentials returns the number of credentials [utx] requires:
+// one per input, plus one for the subnet auth if any.
+func numCredentials(utx platformvm.UnsignedTx) (int, error) {
+	switch tx := utx.(type) {
+	case *platformvm.UnsignedCreateSubnetTx:
+		return len(tx.Ins), nil
+	case *platformvm.UnsignedAddValidatorTx:
+		return len(tx.Ins), nil
+	case *platformvm.UnsignedAddDelegatorTx:
+		return len(tx.Ins), nil
+	case *platformvm.UnsignedExportTx:
+		return len(tx.Ins), nil
+	case *platformvm.UnsignedImportTx:
+		return len(tx.Ins) + len(tx.ImportedInputs), nil
+	case *platformvm.UnsignedAddSubnetValidatorTx:
+		return len(tx.Ins) + 1, nil
+	case *platformvm.UnsignedCreateChainTx:
+		return len(tx.Ins) + 1, nil
+	default:
+		return 0, fmt.Errorf("%w: %T", ErrUnsupportedTx, utx)
+	}
+}
+
+// txInputs returns the inputs of [utx] in the order of their credentials.
+func txInputs(utx platformvm.UnsignedTx) ([]*djtx.TransferableInput, error) {
+	switch tx := utx.(type) {
+	case *platformvm.UnsignedCreateSubnetTx:
+		return tx.Ins, nil
+	case *platformvm.UnsignedAddValidatorTx:
+		return tx.Ins, nil
+	case *platformvm.UnsignedAddDelegatorTx:
+		return tx.Ins, nil
+	case *platformvm.UnsignedExportTx:
+		return tx.Ins, nil
+	case *platformvm.UnsignedImportTx:
+		ins := make([]*djtx.TransferableInput, 0, len(tx.Ins)+len(tx.ImportedInputs))
+		return append(append(ins, tx.Ins...), tx.ImportedInputs...), nil
+	case *platformvm.UnsignedAddSubnetValidatorTx:
+		return tx.Ins, nil
+	case *platformvm.UnsignedCreateChainTx:
+		return tx.Ins, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedTx, utx)
+	}
+}
@@ -6,14 +6,14 @@ package key
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"strings"
 
-	"github.com/lasthyphen/subnet-cli/internal/codec"
-
 	"github.com/lasthyphen/dijetsnodego/ids"
 	"github.com/lasthyphen/dijetsnodego/utils/crypto"
 	"github.com/lasthyphen/dijetsnodego/utils/formatting"
@@ -28,9 +28,10 @@ var (
 	ErrInvalidPrivateKeyLen      = errors.New("invalid private key length (expect 64 bytes in hex)")
 	ErrInvalidPrivateKeyEnding   = errors.New("invalid private key ending")
 	ErrInvalidPrivateKeyEncoding = errors.New("invalid private key encoding")
+	ErrUnknownSigner             = errors.New("unknown signer")
 )
 
-var _ Key = &SoftKey{}
+var _ MultiKey = &SoftKey{}
 
 type SoftKey struct {
 	privKey        *crypto.PrivateKeySECP256K1R
@@ -40,6 +41,12 @@ type SoftKey struct {
 	pAddr string
 
 	keyChain *secp256k1fx.Keychain
+	// signers are the keys that own each UTXO spent via "Spends",
+	// by input ID, to sign with several keys (ref. "NewSoftMulti")
+	signers map[ids.ID][]*crypto.PrivateKeySECP256K1R
+	// authKey signs the credentials that aren't of an input (i.e., the
+	// subnet auth), nil for the primary key (ref. "SetAuthSigner")
+	authKey *crypto.PrivateKeySECP256K1R
 }
 
 const (
@@ -72,7 +79,8 @@ func WithPrivateKey(privKey *crypto.PrivateKeySECP256K1R) SOpOption {
 	}
 }
 
-// To create a new key SoftKey with a pre-defined private key.
+// To create a new key SoftKey with a pre-defined private key, either
+// "PrivateKey-" prefixed CB58, 64-char hex or "0x" prefixed hex.
 func WithPrivateKeyEncoded(privKey string) SOpOption {
 	return func(sop *SOp) {
 		sop.privKeyEncoded = privKey
@@ -117,8 +125,9 @@ func NewSoft(networkID uint32, opts ...SOpOption) (*SoftKey, error) {
 	}
 
 	// double-check encoding is consistent
-	if ret.privKeyEncoded != "" &&
-		ret.privKeyEncoded != privKeyEncoded {
+	// (hex-encoded keys are normalized to CB58 instead)
+	if strings.HasPrefix(ret.privKeyEncoded, privKeyEncPfx) &&
+		strings.TrimSpace(ret.privKeyEncoded) != privKeyEncoded {
 		return nil, ErrInvalidPrivateKeyEncoding
 	}
 
@@ -143,6 +152,45 @@ func NewSoft(networkID uint32, opts ...SOpOption) (*SoftKey, error) {
 	return m, nil
 }
 
+// NewSoftMulti creates a SoftKey from several raw private keys of one
+// logical wallet (e.g., to recover and consolidate funds spread across
+// them), so that it spends the UTXOs of any of the keys and signs each
+// input with the keys that own it. Identical keys are only added once.
+//
+// The first key is the primary one: "P", "Address", and the backups are
+// of that key, and it signs any input it didn't spend itself (e.g., a raw
+// tx built elsewhere) and, unless "SetAuthSigner" picks another key, the
+// subnet auth.
+func NewSoftMulti(networkID uint32, rawKeys [][]byte) (*SoftKey, error) {
+	if len(rawKeys) == 0 {
+		return nil, fmt.Errorf("%w: no keys", ErrInvalidPrivateKey)
+	}
+	var m *SoftKey
+	for i, raw := range rawKeys {
+		rpk, err := keyFactory.ToPrivateKey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %d (%v)", ErrInvalidPrivateKey, i, err)
+		}
+		privKey, ok := rpk.(*crypto.PrivateKeySECP256K1R)
+		if !ok {
+			return nil, ErrInvalidType
+		}
+		if m == nil {
+			m, err = NewSoft(networkID, WithPrivateKey(privKey))
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if m.keyChain.Addrs.Contains(privKey.PublicKey().Address()) {
+			// duplicate
+			continue
+		}
+		m.keyChain.Add(privKey)
+	}
+	return m, nil
+}
+
 // Returns the private key.
 func (m *SoftKey) Key() *crypto.PrivateKeySECP256K1R {
 	return m.privKey
@@ -164,8 +212,18 @@ func (m *SoftKey) Save(p string) error {
 	return ioutil.WriteFile(p, []byte(k), fsModeWrite)
 }
 
+// Returns the private key in hex (as "Save" writes it) and in CB58
+// (as "Encode" returns it), with the P-Chain address, for a complete
+// backup of the key. The values are secret, so it never logs them.
+func (m *SoftKey) Backup() (hexKey string, cb58Key string, address string) {
+	return hex.EncodeToString(m.privKeyRaw), m.privKeyEncoded, m.pAddr
+}
+
 func (m *SoftKey) P() string { return m.pAddr }
 
+// Close is a no-op, as the private key is held in memory.
+func (m *SoftKey) Close() error { return nil }
+
 func (m *SoftKey) Spends(outputs []*djtx.UTXO, opts ...OpOption) (
 	totalBalanceToSpend uint64,
 	inputs []*djtx.TransferableInput,
@@ -201,10 +259,14 @@ func (m *SoftKey) spend(output *djtx.UTXO, time uint64) (
 ) {
 	// "time" is used to check whether the key owner
 	// is still within the lock time (thus can't spend).
-	inputf, _, err := m.keyChain.Spend(output.Out, time)
+	inputf, signers, err := m.keyChain.Spend(output.Out, time)
 	if err != nil {
 		return nil, err
 	}
+	if m.signers == nil {
+		m.signers = make(map[ids.ID][]*crypto.PrivateKeySECP256K1R)
+	}
+	m.signers[output.InputID()] = signers
 	var ok bool
 	input, ok = inputf.(djtx.TransferableIn)
 	if !ok {
@@ -300,11 +362,30 @@ func encodePrivateKey(pk *crypto.PrivateKeySECP256K1R) (string, error) {
 	return privKeyEncPfx + enc, nil
 }
 
+// decodePrivateKey decodes the private key from "PrivateKey-" prefixed
+// CB58, bare 64-char hex or "0x" prefixed hex.
 func decodePrivateKey(enc string) (*crypto.PrivateKeySECP256K1R, error) {
-	rawPk := strings.Replace(enc, privKeyEncPfx, "", 1)
-	skBytes, err := formatting.Decode(formatting.CB58, rawPk)
+	enc = strings.TrimSpace(enc)
+
+	var (
+		skBytes []byte
+		err     error
+	)
+	switch {
+	case strings.HasPrefix(enc, "0x") || strings.HasPrefix(enc, "0X"):
+		if len(enc)-2 != privKeySize {
+			return nil, ErrInvalidPrivateKeyLen
+		}
+		skBytes, err = hex.DecodeString(enc[2:])
+	case len(enc) == privKeySize:
+		// CB58-encoded keys are never this long
+		skBytes, err = hex.DecodeString(enc)
+	default:
+		rawPk := strings.Replace(enc, privKeyEncPfx, "", 1)
+		skBytes, err = formatting.Decode(formatting.CB58, rawPk)
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKeyEncoding, err)
 	}
 	rpk, err := keyFactory.ToPrivateKey(skBytes)
 	if err != nil {
@@ -321,11 +402,113 @@ func (m *SoftKey) Address() ids.ShortID {
 	return m.privKey.PublicKey().Address()
 }
 
-func (m *SoftKey) Sign(pTx *platformvm.Tx, sigs int) error {
-	signers := make([][]*crypto.PrivateKeySECP256K1R, sigs)
-	for i := 0; i < sigs; i++ {
-		signers[i] = []*crypto.PrivateKeySECP256K1R{m.privKey}
+// Match returns true if any of the keys (ref. "NewSoftMulti") has [addr].
+func (m *SoftKey) Match(addr ids.ShortID) bool {
+	return m.keyChain.Addrs.Contains(addr)
+}
+
+// SetAuthSigner sets the key of [addr] to sign the credentials that
+// aren't of an input (i.e., the subnet auth) with, instead of the primary
+// key. It fails with "ErrUnknownSigner" if no key has [addr].
+func (m *SoftKey) SetAuthSigner(addr ids.ShortID) error {
+	for _, k := range m.keyChain.Keys {
+		if k.PublicKey().Address() != addr {
+			continue
+		}
+		m.authKey = k
+		if addr == m.Address() {
+			m.authKey = nil
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownSigner, addr)
+}
+
+func (m *SoftKey) PublicKey() ([]byte, error) {
+	return m.privKey.PublicKey().Bytes(), nil
+}
+
+// Sign transaction with the private key
+//
+// This is a slightly modified version of *platformvm.Tx.Sign(),
+// marshaling with the codec version registered for the tx type, and
+// signing once per distinct signer (ref. "DedupSigners").
+func (m *SoftKey) Sign(ctx context.Context, pTx *platformvm.Tx, sigs int) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrSigningCancelled, err)
+	}
+	hash, unsignedBytes, err := SigningHash(pTx.UnsignedTx)
+	if err != nil {
+		return err
+	}
+	perCred, err := m.credSigners(pTx.UnsignedTx, sigs)
+	if err != nil {
+		return err
+	}
+	return signDeduped(pTx, unsignedBytes, perCred, func(signer ids.ShortID) ([]byte, error) {
+		return m.SignHash(ctx, signer, hash)
+	})
+}
+
+// SignHash signs [hash] with the key of [addr], which may be any of the
+// keys (ref. "NewSoftMulti").
+func (m *SoftKey) SignHash(ctx context.Context, addr ids.ShortID, hash []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSigningCancelled, err)
+	}
+	if !m.Match(addr) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSigner, addr)
+	}
+	sig, err := m.keyOf(addr).SignHash(hash)
+	if err != nil {
+		return nil, fmt.Errorf("problem generating credential: %w", err)
+	}
+	return sig, nil
+}
+
+// keyOf returns the key of [addr], which is one of the keys since it's
+// from "credSigners".
+func (m *SoftKey) keyOf(addr ids.ShortID) *crypto.PrivateKeySECP256K1R {
+	for _, k := range m.keyChain.Keys {
+		if k.PublicKey().Address() == addr {
+			return k
+		}
 	}
+	return m.privKey
+}
 
-	return pTx.Sign(codec.PCodecManager, signers)
+// credSigners returns the signers of each of the [numSigs] credentials:
+// the keys that own the UTXO of each input spent via "Spends", the
+// primary key for any other input, and the auth key (the primary key
+// by default) for the rest.
+func (m *SoftKey) credSigners(utx platformvm.UnsignedTx, numSigs int) ([][]ids.ShortID, error) {
+	if len(m.keyChain.Keys) == 1 {
+		// every input is of the one key (e.g., a raw tx of any type)
+		return singleSigner(m.Address(), numSigs), nil
+	}
+	ins, err := txInputs(utx)
+	if err != nil {
+		return nil, err
+	}
+	authAddr := m.Address()
+	if m.authKey != nil {
+		authAddr = m.authKey.PublicKey().Address()
+	}
+	perCred := make([][]ids.ShortID, numSigs)
+	for i := range perCred {
+		if i >= len(ins) {
+			perCred[i] = []ids.ShortID{authAddr}
+			continue
+		}
+		signers, ok := m.signers[ins[i].InputID()]
+		if !ok {
+			perCred[i] = []ids.ShortID{m.Address()}
+			continue
+		}
+		perCred[i] = make([]ids.ShortID, len(signers))
+		for j, k := range signers {
+			perCred[i][j] = k.PublicKey().Address()
+		}
+	}
+	return perCred, nil
 }
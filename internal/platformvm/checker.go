@@ -28,6 +28,10 @@ type Checker interface {
 	PollTx(ctx context.Context, txID ids.ID, s pstatus.Status) (time.Duration, error)
 	PollSubnet(ctx context.Context, subnetID ids.ID) (time.Duration, error)
 	PollBlockchain(ctx context.Context, opts ...OpOption) (time.Duration, error)
+	// PollChainRPC polls the [probe] until it succeeds, meaning the
+	// RPC endpoint of the blockchain is live (e.g., "eth_chainId" call
+	// for subnet-evm), not just that the blockchain has been created.
+	PollChainRPC(ctx context.Context, blockchainID ids.ID, probe func() error) (time.Duration, error)
 }
 
 var _ Checker = &checker{}
@@ -136,7 +140,11 @@ func (c *checker) PollBlockchain(ctx context.Context, opts ...OpOption) (took ti
 		return took, err
 	}
 
+	// the status and the bootstrap are checked in the same poll, so that
+	// an already bootstrapped blockchain (e.g., re-running a deploy against
+	// a live subnet) returns on the first check with no poll interval
 	statusPolled := false
+	var bootstrapStart, lastReport time.Time
 	prev = took
 	took, err = c.poller.Poll(ctx, func() (done bool, err error) {
 		if !statusPolled {
@@ -156,12 +164,22 @@ func (c *checker) PollBlockchain(ctx context.Context, opts ...OpOption) (took ti
 			}
 		}
 
+		if bootstrapStart.IsZero() {
+			bootstrapStart = time.Now()
+			if ret.bootstrapStart != nil {
+				ret.bootstrapStart()
+			}
+		}
 		bootstrapped, err := ret.info.IsBootstrapped(ctx, ret.blockchainID.String())
 		if err != nil {
 			return false, err
 		}
 		if !bootstrapped {
 			zap.L().Debug("blockchain not bootstrapped yet; retrying")
+			if ret.bootstrapProgress != nil && time.Since(lastReport) >= bootstrapProgressInterval {
+				lastReport = time.Now()
+				ret.bootstrapProgress(c.bootstrapProgress(ctx, ret, time.Since(bootstrapStart)))
+			}
 			return false, nil
 		}
 		return true, nil
@@ -170,6 +188,61 @@ func (c *checker) PollBlockchain(ctx context.Context, opts ...OpOption) (took ti
 	return took, err
 }
 
+// bootstrapProgressInterval is the minimum interval between two
+// bootstrap progress reports, so they don't flood the output.
+const bootstrapProgressInterval = 5 * time.Second
+
+// BootstrapProgress is the progress of waiting for a blockchain to
+// bootstrap.
+type BootstrapProgress struct {
+	Elapsed time.Duration
+	// Percent is the completion in [0, 100], or negative if unknown.
+	Percent float64
+	// ETA is the estimated remaining time, or 0 if unknown.
+	ETA time.Duration
+}
+
+func (c *checker) bootstrapProgress(ctx context.Context, ret *Op, elapsed time.Duration) BootstrapProgress {
+	p := BootstrapProgress{Elapsed: elapsed, Percent: -1}
+	if ret.bootstrapPercent == nil {
+		return p
+	}
+	percent, err := ret.bootstrapPercent(ctx)
+	if err != nil {
+		zap.L().Debug("failed to get bootstrap percent", zap.Error(err))
+		return p
+	}
+	p.Percent = percent
+	p.ETA = estimateETA(elapsed, percent)
+	return p
+}
+
+// estimateETA extrapolates the remaining time from the [elapsed] time to
+// reach [percent], assuming a constant rate.
+func estimateETA(elapsed time.Duration, percent float64) time.Duration {
+	if percent <= 0 || percent >= 100 {
+		return 0
+	}
+	return time.Duration(float64(elapsed) * (100 - percent) / percent).Round(time.Second)
+}
+
+func (c *checker) PollChainRPC(ctx context.Context, blockchainID ids.ID, probe func() error) (took time.Duration, err error) {
+	if blockchainID == ids.Empty {
+		return took, ErrEmptyID
+	}
+
+	zap.L().Info("polling blockchain RPC",
+		zap.String("blockchainId", blockchainID.String()),
+	)
+	return c.poller.Poll(ctx, func() (done bool, err error) {
+		if err := probe(); err != nil {
+			zap.L().Debug("blockchain RPC not ready yet; retrying", zap.Error(err))
+			return false, nil
+		}
+		return true, nil
+	})
+}
+
 func (c *checker) findBlockchain(ctx context.Context, subnetID ids.ID) (bchID ids.ID, took time.Duration, err error) {
 	zap.L().Info("finding blockchains",
 		zap.String("subnetId", subnetID.String()),
@@ -186,7 +259,7 @@ func (c *checker) findBlockchain(ctx context.Context, subnetID ids.ID) (bchID id
 				break
 			}
 		}
-		return bchID == ids.Empty, nil
+		return bchID != ids.Empty, nil
 	})
 	return bchID, took, err
 }
@@ -199,6 +272,10 @@ type Op struct {
 
 	info                        info.Client
 	checkBlockchainBootstrapped bool
+
+	bootstrapProgress func(BootstrapProgress)
+	bootstrapPercent  func(context.Context) (float64, error)
+	bootstrapStart    func()
 }
 
 type OpOption func(*Op)
@@ -235,3 +312,28 @@ func WithCheckBlockchainBootstrapped(info info.Client) OpOption {
 		op.checkBlockchainBootstrapped = true
 	}
 }
+
+// To report the elapsed time while waiting for the blockchain to bootstrap,
+// with "WithCheckBlockchainBootstrapped".
+func WithBootstrapProgress(f func(BootstrapProgress)) OpOption {
+	return func(op *Op) {
+		op.bootstrapProgress = f
+	}
+}
+
+// To be notified once the blockchain reaches the expected status and the
+// checker starts waiting for it to bootstrap.
+func WithBootstrapStart(f func()) OpOption {
+	return func(op *Op) {
+		op.bootstrapStart = f
+	}
+}
+
+// To get the bootstrap completion percentage (e.g., from a VM-specific
+// API), for "WithBootstrapProgress" to report an ETA. The node itself only
+// reports whether the blockchain is bootstrapped.
+func WithBootstrapPercent(f func(context.Context) (float64, error)) OpOption {
+	return func(op *Op) {
+		op.bootstrapPercent = f
+	}
+}
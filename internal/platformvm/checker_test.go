@@ -7,6 +7,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
+
+	"github.com/lasthyphen/dijetsnodego/api/info"
+	"github.com/lasthyphen/dijetsnodego/ids"
+	"github.com/lasthyphen/dijetsnodego/utils/rpc"
+	"github.com/lasthyphen/dijetsnodego/vms/platformvm"
+	pstatus "github.com/lasthyphen/dijetsnodego/vms/platformvm/status"
+	"github.com/lasthyphen/subnet-cli/internal/poll"
 )
 
 func TestChecker(t *testing.T) {
@@ -18,3 +26,185 @@ func TestChecker(t *testing.T) {
 		t.Fatalf("unexpected error %v, expected %v", err, ErrEmptyID)
 	}
 }
+
+// fakeClient never reports the polled resources as ready,
+// so that the polls only return once canceled.
+type fakeClient struct {
+	platformvm.Client
+	txStatus pstatus.Status
+}
+
+func (f *fakeClient) GetTxStatus(context.Context, ids.ID, bool, ...rpc.Option) (*platformvm.GetTxStatusResponse, error) {
+	return &platformvm.GetTxStatusResponse{Status: f.txStatus}, nil
+}
+
+func (f *fakeClient) GetSubnets(context.Context, []ids.ID, ...rpc.Option) ([]platformvm.ClientSubnet, error) {
+	return nil, nil
+}
+
+func (f *fakeClient) GetBlockchains(context.Context, ...rpc.Option) ([]platformvm.APIBlockchain, error) {
+	return nil, nil
+}
+
+func (f *fakeClient) GetBlockchainStatus(context.Context, string, ...rpc.Option) (pstatus.BlockchainStatus, error) {
+	return pstatus.Syncing, nil
+}
+
+func TestCheckerCancel(t *testing.T) {
+	t.Parallel()
+
+	interval := 100 * time.Millisecond
+	tt := []struct {
+		name     string
+		txStatus pstatus.Status
+		poll     func(ctx context.Context, ck Checker) error
+	}{
+		{
+			name:     "PollTx",
+			txStatus: pstatus.Processing,
+			poll: func(ctx context.Context, ck Checker) error {
+				_, err := ck.PollTx(ctx, ids.GenerateTestID(), pstatus.Committed)
+				return err
+			},
+		},
+		{
+			name:     "PollSubnet",
+			txStatus: pstatus.Committed,
+			poll: func(ctx context.Context, ck Checker) error {
+				_, err := ck.PollSubnet(ctx, ids.GenerateTestID())
+				return err
+			},
+		},
+		{
+			name:     "PollBlockchain with subnet ID",
+			txStatus: pstatus.Committed,
+			poll: func(ctx context.Context, ck Checker) error {
+				_, err := ck.PollBlockchain(ctx, WithSubnetID(ids.GenerateTestID()))
+				return err
+			},
+		},
+		{
+			name:     "PollBlockchain with blockchain ID",
+			txStatus: pstatus.Committed,
+			poll: func(ctx context.Context, ck Checker) error {
+				_, err := ck.PollBlockchain(ctx,
+					WithBlockchainID(ids.GenerateTestID()),
+					WithBlockchainStatus(pstatus.Validating),
+				)
+				return err
+			},
+		},
+	}
+	for i, tv := range tt {
+		ck := NewChecker(poll.New(interval), &fakeClient{txStatus: tv.txStatus})
+
+		// cancel in between two polls
+		ctx, cancel := context.WithCancel(context.Background())
+		canceled := make(chan time.Time, 1)
+		time.AfterFunc(interval*3/2, func() {
+			canceled <- time.Now()
+			cancel()
+		})
+
+		err := tv.poll(ctx, ck)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, context.Canceled)
+		}
+		if took := time.Since(<-canceled); took > interval {
+			t.Fatalf("#%d(%s): took %v to return after cancel, expected <=%v", i, tv.name, took, interval)
+		}
+	}
+}
+
+// chainsClient lists the blockchains of the subnets.
+type chainsClient struct {
+	fakeClient
+	blockchains []platformvm.APIBlockchain
+}
+
+func (c *chainsClient) GetBlockchains(context.Context, ...rpc.Option) ([]platformvm.APIBlockchain, error) {
+	return c.blockchains, nil
+}
+
+func TestFindBlockchain(t *testing.T) {
+	t.Parallel()
+
+	subnetID, otherSubnetID := ids.GenerateTestID(), ids.GenerateTestID()
+	bchID := ids.GenerateTestID()
+	ck := NewChecker(poll.New(time.Millisecond), &chainsClient{
+		blockchains: []platformvm.APIBlockchain{
+			{ID: ids.GenerateTestID(), SubnetID: otherSubnetID},
+			{ID: bchID, SubnetID: subnetID},
+		},
+	}).(*checker)
+
+	// returns on the first poll that lists the blockchain
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	found, _, err := ck.findBlockchain(ctx, subnetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != bchID {
+		t.Fatalf("unexpected blockchain %s, expected %s", found, bchID)
+	}
+}
+
+// liveClient reports the blockchain as already validated.
+type liveClient struct {
+	fakeClient
+}
+
+func (*liveClient) GetBlockchainStatus(context.Context, string, ...rpc.Option) (pstatus.BlockchainStatus, error) {
+	return pstatus.Validating, nil
+}
+
+type bootstrappedInfo struct {
+	info.Client
+}
+
+func (*bootstrappedInfo) IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error) {
+	return true, nil
+}
+
+func TestPollBlockchainAlreadyBootstrapped(t *testing.T) {
+	t.Parallel()
+
+	// long enough that any wait for a second poll would time out
+	interval := time.Minute
+	ck := NewChecker(poll.New(interval), &liveClient{fakeClient{txStatus: pstatus.Committed}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	took, err := ck.PollBlockchain(ctx,
+		WithBlockchainID(ids.GenerateTestID()),
+		WithBlockchainStatus(pstatus.Validating),
+		WithCheckBlockchainBootstrapped(&bootstrappedInfo{}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if took >= time.Second {
+		t.Fatalf("took %v, expected to return on the first check", took)
+	}
+}
+
+func TestEstimateETA(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		elapsed time.Duration
+		percent float64
+		eta     time.Duration
+	}{
+		{elapsed: time.Minute, percent: 50, eta: time.Minute},
+		{elapsed: time.Minute, percent: 25, eta: 3 * time.Minute},
+		{elapsed: time.Minute, percent: 0, eta: 0},
+		{elapsed: time.Minute, percent: 100, eta: 0},
+	}
+	for i, tv := range tt {
+		if eta := estimateETA(tv.elapsed, tv.percent); eta != tv.eta {
+			t.Fatalf("#%d: unexpected ETA %v, expected %v", i, eta, tv.eta)
+		}
+	}
+}
@@ -21,3 +21,25 @@ func TestPoll(t *testing.T) {
 		t.Fatalf("unexpected Poll error %v", err)
 	}
 }
+
+func TestPollCancel(t *testing.T) {
+	t.Parallel()
+
+	interval := 100 * time.Millisecond
+	pl := New(interval)
+
+	// cancel in between two polls
+	rootCtx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan time.Time, 1)
+	time.AfterFunc(interval*3/2, func() {
+		canceled <- time.Now()
+		cancel()
+	})
+	_, err := pl.Poll(rootCtx, func() (bool, error) { return false, nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected Poll error %v", err)
+	}
+	if took := time.Since(<-canceled); took > interval {
+		t.Fatalf("took %v to return after cancel, expected <=%v", took, interval)
+	}
+}
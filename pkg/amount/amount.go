@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package amount implements DJTX amount parsing and formatting.
+package amount
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lasthyphen/dijetsnodego/utils/units"
+)
+
+const (
+	unitSuffix = "DJTX"
+
+	// number of decimal places of "units.Djtx" (1 DJTX = 10^9 nano-DJTX)
+	decimals = 9
+)
+
+var (
+	ErrInvalidAmount  = errors.New("invalid DJTX amount")
+	ErrNegativeAmount = errors.New("negative DJTX amount")
+	ErrAmountOverflow = errors.New("DJTX amount overflows uint64 nano-DJTX")
+	ErrTooPrecise     = errors.New("DJTX amount more precise than nano-DJTX")
+)
+
+// ParseDJTX parses the DJTX amount (e.g., "2000", "2000.5", "1.5 DJTX")
+// and returns it in nano-DJTX.
+func ParseDJTX(s string) (uint64, error) {
+	v := strings.TrimSpace(s)
+	if len(v) >= len(unitSuffix) && strings.EqualFold(v[len(v)-len(unitSuffix):], unitSuffix) {
+		v = strings.TrimSpace(v[:len(v)-len(unitSuffix)])
+	}
+	if strings.HasPrefix(v, "-") {
+		return 0, fmt.Errorf("%w: %q", ErrNegativeAmount, s)
+	}
+
+	whole, frac := v, ""
+	if idx := strings.IndexByte(v, '.'); idx >= 0 {
+		whole, frac = v[:idx], v[idx+1:]
+	}
+	if (whole == "" && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	// trailing zeros don't add precision
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > decimals {
+		return 0, fmt.Errorf("%w: %q", ErrTooPrecise, s)
+	}
+
+	w := uint64(0)
+	if whole != "" {
+		var err error
+		w, err = strconv.ParseUint(whole, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrAmountOverflow, s)
+		}
+	}
+	f := uint64(0)
+	if frac != "" {
+		// cannot fail, at most 9 digits
+		f, _ = strconv.ParseUint(frac+strings.Repeat("0", decimals-len(frac)), 10, 64)
+	}
+	if w > (math.MaxUint64-f)/units.Djtx {
+		return 0, fmt.Errorf("%w: %q", ErrAmountOverflow, s)
+	}
+	return w*units.Djtx + f, nil
+}
+
+// FormatDJTX formats the nano-DJTX amount in DJTX (e.g., "2000.5"),
+// without trailing zeros. It is the inverse of "ParseDJTX".
+func FormatDJTX(nDJTX uint64) string {
+	whole, frac := nDJTX/units.Djtx, nDJTX%units.Djtx
+	if frac == 0 {
+		return strconv.FormatUint(whole, 10)
+	}
+	fracs := strings.TrimRight(fmt.Sprintf("%0*d", decimals, frac), "0")
+	return fmt.Sprintf("%d.%s", whole, fracs)
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package amount
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDJTX(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		s      string
+		nDJTX  uint64
+		expErr error
+	}{
+		{s: "2000", nDJTX: 2000000000000},
+		{s: "2000.5", nDJTX: 2000500000000},
+		{s: "1.5 DJTX", nDJTX: 1500000000},
+		{s: "1.5djtx", nDJTX: 1500000000},
+		{s: ".000000001", nDJTX: 1},
+		{s: "0.1000000000", nDJTX: 100000000},
+		{s: "18446744073.709551615", nDJTX: 18446744073709551615},
+		{s: "18446744073.709551616", expErr: ErrAmountOverflow},
+		{s: "99999999999999999999", expErr: ErrAmountOverflow},
+		{s: "0.0000000001", expErr: ErrTooPrecise},
+		{s: "-1", expErr: ErrNegativeAmount},
+		{s: "", expErr: ErrInvalidAmount},
+		{s: ".", expErr: ErrInvalidAmount},
+		{s: "1,000", expErr: ErrInvalidAmount},
+		{s: "1e9", expErr: ErrInvalidAmount},
+		{s: "+1", expErr: ErrInvalidAmount},
+	}
+	for i, tv := range tt {
+		nDJTX, err := ParseDJTX(tv.s)
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.s, err, tv.expErr)
+		}
+		if nDJTX != tv.nDJTX {
+			t.Fatalf("#%d(%s): unexpected amount %d, expected %d", i, tv.s, nDJTX, tv.nDJTX)
+		}
+		if tv.expErr != nil {
+			continue
+		}
+		if v, err := ParseDJTX(FormatDJTX(nDJTX)); err != nil || v != nDJTX {
+			t.Fatalf("#%d(%s): unexpected round trip %d (%v), expected %d", i, tv.s, v, err, nDJTX)
+		}
+	}
+}
@@ -133,15 +133,20 @@ var _ = ginkgo.Describe("[CreateSubnet/CreateBlockchain]", func() {
 		expectedBalance := balance - subnetTxFee
 
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		subnet1, _, err := cli.P().CreateSubnet(ctx, k, client.WithDryMode(true))
+		subnet1, _, _, err := cli.P().CreateSubnet(ctx, k, client.WithDryMode(true))
 		cancel()
 		gomega.Ω(err).Should(gomega.BeNil())
 
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
-		subnet2, _, err := cli.P().CreateSubnet(ctx, k, client.WithDryMode(false))
+		subnet2, owner, _, err := cli.P().CreateSubnet(ctx, k, client.WithDryMode(false))
 		cancel()
 		gomega.Ω(err).Should(gomega.BeNil())
 
+		ginkgo.By("returns the key as the subnet owner", func() {
+			gomega.Ω(owner.Threshold).Should(gomega.Equal(uint32(1)))
+			gomega.Ω(owner.ControlAddrs).Should(gomega.Equal([]string{k.P()}))
+		})
+
 		ginkgo.By("returns an identical subnet ID with dry mode", func() {
 			gomega.Ω(subnet1).Should(gomega.Equal(subnet2))
 		})
@@ -241,7 +246,7 @@ var _ = ginkgo.Describe("[CreateSubnet/CreateBlockchain]", func() {
 	ginkgo.It("can issue CreateBlockchain", func() {
 		ginkgo.By("fails when subnet ID is empty", func() {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			_, _, err := cli.P().CreateBlockchain(
+			_, _, _, err := cli.P().CreateBlockchain(
 				ctx,
 				k,
 				ids.Empty,
@@ -255,7 +260,7 @@ var _ = ginkgo.Describe("[CreateSubnet/CreateBlockchain]", func() {
 
 		ginkgo.By("fails when vm ID is empty", func() {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			_, _, err := cli.P().CreateBlockchain(
+			_, _, _, err := cli.P().CreateBlockchain(
 				ctx,
 				k,
 				subnetID,